@@ -1,3 +1,3 @@
 module github.com/pierre-primary/go-task
 
-go 1.13
+go 1.21
@@ -0,0 +1,105 @@
+package task_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pierre-primary/go-task"
+)
+
+func Test_Cancel(t *testing.T) {
+	t.Run("RunContext", func(t *testing.T) {
+		started := make(chan struct{})
+		stopped := make(chan error, 1)
+		tk := task.RunContext(func(ctx context.Context) (any, error) {
+			close(started)
+			<-ctx.Done()
+			stopped <- ctx.Err()
+			return nil, ctx.Err()
+		})
+		<-started
+		cause := errors.New("Cancel")
+		if !tk.(*task.TaskImpl).Cancel(cause) {
+			t.Error("取消失败")
+		}
+		if err := <-stopped; err != context.Canceled {
+			t.Error("错误的结果", err)
+		}
+		if !tk.IsCanceled() || tk.Error() != cause {
+			t.Error("错误的状态", tk.State(), tk.Error())
+		}
+		if tk.(*task.TaskImpl).Cancel(nil) {
+			t.Error("重复取消")
+		}
+	})
+	t.Run("Parent", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		tk := task.RunContext(func(ctx context.Context) (any, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}, ctx)
+		cancel()
+		if tk.Wait(); !tk.IsCanceled() {
+			t.Error("错误的状态", tk.State())
+		}
+	})
+	t.Run("Follower", func(t *testing.T) {
+		src := task.Delay(time.Hour)
+		flw := src.Then(func(any) (any, error) {
+			t.Error("错误的路径")
+			return nil, nil
+		})
+		src.(*task.TaskImpl).Cancel(nil)
+		if flw.Wait(); !flw.IsCanceled() || flw.Error() != task.Canceled() {
+			t.Error("错误的状态", flw.State(), flw.Error())
+		}
+	})
+	t.Run("Pending", func(t *testing.T) {
+		src, resolve, _ := task.New()
+		flw := src.Then(func(any) (any, error) {
+			t.Error("错误的路径")
+			return nil, nil
+		})
+		flw.(*task.TaskImpl).Cancel(nil)
+		resolve(1)
+		if flw.Wait(); !flw.IsCanceled() {
+			t.Error("错误的状态", flw.State())
+		}
+	})
+}
+
+func Test_LinkedCancel(t *testing.T) {
+	started := make(chan struct{})
+	stopped := make(chan struct{})
+	src := task.RunContext(func(ctx context.Context) (any, error) {
+		close(started)
+		<-ctx.Done()
+		close(stopped)
+		return nil, ctx.Err()
+	}, task.WithLinkedCancel(context.Background()))
+	<-started
+
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	flw1 := src.Then(func(any) (any, error) { return nil, nil }, ctx1)
+	flw2 := src.Then(func(any) (any, error) { return nil, nil }).Then(func(any) (any, error) { return nil, nil }, ctx2)
+
+	cancel1()
+	if flw1.Wait(); !flw1.IsCanceled() {
+		t.Error("错误的状态", flw1.State())
+	}
+	if src.IsDone() {
+		t.Error("错误的状态", src.State())
+	}
+	cancel2()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("上游任务未取消")
+	}
+	if src.Wait(); !src.IsCanceled() || !flw2.Wait().IsCanceled() {
+		t.Error("错误的状态", src.State(), flw2.State())
+	}
+}
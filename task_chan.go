@@ -0,0 +1,74 @@
+package task
+
+import (
+	"context"
+)
+
+/* FromChan */
+
+// Create a task that resolves to the first value received from the channel,
+// rejects with NoValue if the channel is closed without any value,
+// or is canceled when the context is canceled.
+func FromChan(ch <-chan interface{}, ctxs ...context.Context) Task {
+	ctx := firstContext(ctxs, nil)
+	if ctx != nil && isCanceledContext(ctx) {
+		return Cancel(ctx.Err())
+	}
+	task, taskCtx := newCancelableTask(ctx)
+	var fn taskCaller = func(task *TaskImpl) {
+		select {
+		case v, ok := <-ch:
+			if ok {
+				resolve(task, v)
+			} else {
+				reject(task, noValueError)
+			}
+		case <-taskCtx.Done():
+			cancel(task, taskCtx.Err())
+		}
+	}
+	return startTask(task, fn, ctx)
+}
+
+/* FromErrChan */
+
+// Create a task that waits for the first error received from the channel,
+// it rejects with a non-nil error, and resolves when nil is received or the channel is closed,
+// or is canceled when the context is canceled.
+func FromErrChan(ch <-chan error, ctxs ...context.Context) Task {
+	ctx := firstContext(ctxs, nil)
+	if ctx != nil && isCanceledContext(ctx) {
+		return Cancel(ctx.Err())
+	}
+	task, taskCtx := newCancelableTask(ctx)
+	var fn taskCaller = func(task *TaskImpl) {
+		select {
+		case err := <-ch:
+			if err == nil {
+				resolve(task, nil)
+			} else {
+				reject(task, err)
+			}
+		case <-taskCtx.Done():
+			cancel(task, taskCtx.Err())
+		}
+	}
+	return startTask(task, fn, ctx)
+}
+
+/* ToChan */
+
+// Return a channel that delivers the outcome of the task once it is done, and is closed then.
+func ToChan(t Task) <-chan Result {
+	ch := make(chan Result, 1)
+	if t == nil || t.IsDone() {
+		ch <- settledResult(t)
+		close(ch)
+		return ch
+	}
+	observe(t, func(target Task) {
+		ch <- settledResult(target)
+		close(ch)
+	})
+	return ch
+}
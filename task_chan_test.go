@@ -0,0 +1,53 @@
+package task_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pierre-primary/go-task"
+)
+
+func Test_Chan(t *testing.T) {
+	t.Run("FromChan", func(t *testing.T) {
+		ch := make(chan interface{})
+		tk := task.FromChan(ch)
+		ch <- 1
+		if rs := tk.Result(); rs != 1 {
+			t.Error("错误的结果", rs)
+		}
+		close(ch)
+		if err := task.FromChan(ch).Error(); err != task.NoValue() {
+			t.Error("错误的结果", err)
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		tk = task.FromChan(make(chan interface{}), ctx)
+		cancel()
+		if tk.Wait(); !tk.IsCanceled() {
+			t.Error("错误的状态", tk.State())
+		}
+	})
+	t.Run("FromErrChan", func(t *testing.T) {
+		failErr := errors.New("Fail")
+		ch := make(chan error, 1)
+		ch <- failErr
+		if err := task.FromErrChan(ch).Error(); err != failErr {
+			t.Error("错误的结果", err)
+		}
+		close(ch)
+		if tk := task.FromErrChan(ch).Wait(); !tk.IsCompleted() {
+			t.Error("错误的状态", tk.State())
+		}
+	})
+	t.Run("ToChan", func(t *testing.T) {
+		src, resolve, _ := task.New()
+		ch := task.ToChan(src)
+		resolve(1)
+		if rs := <-ch; rs.State != task.STATE_COMPLETED || rs.Value != 1 {
+			t.Error("错误的结果", rs)
+		}
+		if _, ok := <-ch; ok {
+			t.Error("通道未关闭")
+		}
+	})
+}
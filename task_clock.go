@@ -0,0 +1,94 @@
+package task
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// 时钟接口定义
+//
+// Delay / WaitTimeout / Retry / Timeout / WithTimeout 通过时钟计时，
+// 测试中可以替换为可手动推进的虚拟时钟（见 tasktest 包）。
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+	AfterFunc(d time.Duration, fn func()) Timer
+}
+
+// 计时器接口定义
+type Timer interface {
+	// Return the channel on which the time is delivered, nil for the timer created by AfterFunc
+	C() <-chan time.Time
+	Stop() bool
+}
+
+type systemClock struct{}
+
+type systemTimer struct {
+	*time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+func (systemClock) AfterFunc(d time.Duration, fn func()) Timer {
+	return systemTimer{time.AfterFunc(d, fn)}
+}
+
+// 系统时钟
+var SystemClock Clock = systemClock{}
+
+// ------------------------------------------------------------------------------------------------------
+/* Clock Select */
+
+type (
+	clockKey    struct{}
+	clockHolder struct{ clock Clock }
+)
+
+var defaultClock atomic.Value
+
+func init() {
+	defaultClock.Store(clockHolder{SystemClock})
+}
+
+// Set the clock used when the context does not specify one
+func SetDefaultClock(c Clock) {
+	if c == nil {
+		c = SystemClock
+	}
+	defaultClock.Store(clockHolder{c})
+}
+
+// Get the default clock
+func DefaultClock() Clock {
+	return defaultClock.Load().(clockHolder).clock
+}
+
+// Return a context that times the tasks created with it by the clock
+func WithClock(ctx context.Context, c Clock) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, clockKey{}, c)
+}
+
+// Get the clock of the context
+func clockOf(ctx context.Context) Clock {
+	if ctx != nil {
+		if c, ok := ctx.Value(clockKey{}).(Clock); ok && c != nil {
+			return c
+		}
+	}
+	return DefaultClock()
+}
@@ -0,0 +1,250 @@
+package task
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// ------------------------------------------------------------------------------------------------------
+/* Observe */
+
+type observeCaller func(Task)
+
+func (body observeCaller) TryCall(target Task) (bool, interface{}, error) {
+	body(target)
+	return true, nil, nil
+}
+
+// Invoke fn once the target task is done
+func observe(target Task, fn func(Task)) {
+	if task, ok := target.(*TaskImpl); ok {
+		newAsyncFollower(task, observeCaller(fn), nil)
+		return
+	}
+	target.Continue(func(t Task) (interface{}, error) {
+		fn(t)
+		return nil, nil
+	})
+}
+
+// ------------------------------------------------------------------------------------------------------
+/* WhenAll */
+
+type whenAll struct {
+	task    *TaskImpl
+	results []interface{}
+	remain  int32
+}
+
+func (all *whenAll) settle(index int, target Task) {
+	if target != nil {
+		switch target.State() {
+		case STATE_COMPLETED:
+			all.results[index] = target.Result()
+		case STATE_CANCELED:
+			cancel(all.task, target.Error())
+			return
+		default:
+			reject(all.task, target.Error())
+			return
+		}
+	}
+	if atomic.AddInt32(&all.remain, -1) == 0 {
+		resolve(all.task, all.results)
+	}
+}
+
+// Create a task that resolves to the ordered results of all tasks,
+// rejects with the first error, or is canceled when any task is canceled.
+func WhenAll(tasks ...Task) Task {
+	all := &whenAll{
+		task:    newTask(),
+		results: make([]interface{}, len(tasks)),
+		remain:  int32(len(tasks)),
+	}
+	if len(tasks) == 0 {
+		resolve(all.task, all.results)
+		return all.task
+	}
+	for i, t := range tasks {
+		index := i
+		if t == nil {
+			all.settle(index, nil)
+			continue
+		}
+		observe(t, func(target Task) {
+			all.settle(index, target)
+		})
+	}
+	return all.task
+}
+
+// ------------------------------------------------------------------------------------------------------
+/* AllSettled */
+
+// Outcome of a done task
+type Result struct {
+	State TaskState
+	Value interface{}
+	Err   error
+}
+
+// Load the outcome of a done task
+func settledResult(target Task) Result {
+	if target == nil {
+		return Result{State: STATE_COMPLETED}
+	}
+	rs, err := target.Return()
+	return Result{State: target.State(), Value: rs, Err: err}
+}
+
+type allSettled struct {
+	task    *TaskImpl
+	results []Result
+	remain  int32
+}
+
+func (all *allSettled) settle(index int, target Task) {
+	all.results[index] = settledResult(target)
+	if atomic.AddInt32(&all.remain, -1) == 0 {
+		resolve(all.task, all.results)
+	}
+}
+
+// Create a task that resolves to the outcome ([]Result) of every task once all of them are done.
+func AllSettled(tasks ...Task) Task {
+	all := &allSettled{
+		task:    newTask(),
+		results: make([]Result, len(tasks)),
+		remain:  int32(len(tasks)),
+	}
+	if len(tasks) == 0 {
+		resolve(all.task, all.results)
+		return all.task
+	}
+	for i, t := range tasks {
+		index := i
+		if t == nil {
+			all.settle(index, nil)
+			continue
+		}
+		observe(t, func(target Task) {
+			all.settle(index, target)
+		})
+	}
+	return all.task
+}
+
+// ------------------------------------------------------------------------------------------------------
+/* Race & Any */
+
+// Result of the task that won Race or Any
+type Winner struct {
+	Index int
+	Value interface{}
+}
+
+// Error that aggregates the errors of several tasks
+type AggregateError struct {
+	Errors []error
+}
+
+func (e *AggregateError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		if err != nil {
+			msgs = append(msgs, err.Error())
+		}
+	}
+	return "Task: all tasks failed: [" + strings.Join(msgs, "; ") + "]"
+}
+
+func (e *AggregateError) Unwrap() []error {
+	return e.Errors
+}
+
+type race struct {
+	task *TaskImpl
+}
+
+func (r *race) settle(index int, target Task) {
+	if target == nil {
+		resolve(r.task, Winner{Index: index})
+		return
+	}
+	switch target.State() {
+	case STATE_COMPLETED:
+		resolve(r.task, Winner{Index: index, Value: target.Result()})
+	case STATE_CANCELED:
+		cancel(r.task, target.Error())
+	default:
+		reject(r.task, target.Error())
+	}
+}
+
+// Create a task that adopts the outcome of the first done task,
+// it resolves to a Winner, or is faulted / canceled with the error of the first done task.
+// Race without any task is canceled.
+func Race(tasks ...Task) Task {
+	if len(tasks) == 0 {
+		return Cancel()
+	}
+	r := &race{task: newTask()}
+	for i, t := range tasks {
+		index := i
+		if t == nil {
+			r.settle(index, nil)
+			continue
+		}
+		observe(t, func(target Task) {
+			r.settle(index, target)
+		})
+	}
+	return r.task
+}
+
+type anyOf struct {
+	task   *TaskImpl
+	errs   []error
+	remain int32
+}
+
+func (a *anyOf) settle(index int, target Task) {
+	if target == nil {
+		resolve(a.task, Winner{Index: index})
+		return
+	}
+	if target.IsCompleted() {
+		resolve(a.task, Winner{Index: index, Value: target.Result()})
+		return
+	}
+	a.errs[index] = target.Error()
+	if atomic.AddInt32(&a.remain, -1) == 0 {
+		reject(a.task, &AggregateError{Errors: a.errs})
+	}
+}
+
+// Create a task that resolves to a Winner with the first completed task,
+// it rejects with an AggregateError if no task is completed.
+func Any(tasks ...Task) Task {
+	a := &anyOf{
+		task:   newTask(),
+		errs:   make([]error, len(tasks)),
+		remain: int32(len(tasks)),
+	}
+	if len(tasks) == 0 {
+		reject(a.task, &AggregateError{Errors: a.errs})
+		return a.task
+	}
+	for i, t := range tasks {
+		index := i
+		if t == nil {
+			a.settle(index, nil)
+			continue
+		}
+		observe(t, func(target Task) {
+			a.settle(index, target)
+		})
+	}
+	return a.task
+}
@@ -0,0 +1,100 @@
+package task_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pierre-primary/go-task"
+)
+
+func Test_WhenAll(t *testing.T) {
+	t.Run("Resolve", func(t *testing.T) {
+		rs, err := task.WhenAll(
+			task.Resolve(1),
+			task.Delay(time.Millisecond).Then(func(any) (any, error) {
+				return 2, nil
+			}),
+			task.Run(func() (any, error) {
+				return 3, nil
+			}),
+		).Return()
+		if err != nil || !reflect.DeepEqual(rs, []interface{}{1, 2, 3}) {
+			t.Error("错误的结果", rs, err)
+		}
+	})
+	t.Run("Reject", func(t *testing.T) {
+		all := task.WhenAll(task.Resolve(1), task.Reject(errors.New("Reject"))).Wait()
+		if !all.IsFaulted() || all.Error().Error() != "Reject" {
+			t.Error("错误的状态", all.State(), all.Error())
+		}
+	})
+	t.Run("Cancel", func(t *testing.T) {
+		all := task.WhenAll(task.Resolve(1), task.Cancel()).Wait()
+		if !all.IsCanceled() {
+			t.Error("错误的状态", all.State())
+		}
+	})
+	t.Run("Empty", func(t *testing.T) {
+		if rs := task.WhenAll().Result(); !reflect.DeepEqual(rs, []interface{}{}) {
+			t.Error("错误的结果", rs)
+		}
+	})
+}
+
+func Test_AllSettled(t *testing.T) {
+	rejectErr := errors.New("Reject")
+	rs := task.AllSettled(
+		task.Resolve(1),
+		task.Reject(rejectErr),
+		task.Delay(time.Millisecond),
+		task.Cancel(),
+	).Result().([]task.Result)
+	want := []task.Result{
+		{State: task.STATE_COMPLETED, Value: 1},
+		{State: task.STATE_FAULTED, Err: rejectErr},
+		{State: task.STATE_COMPLETED},
+		{State: task.STATE_CANCELED, Err: task.Canceled()},
+	}
+	if !reflect.DeepEqual(rs, want) {
+		t.Error("错误的结果", rs)
+	}
+}
+
+func Test_Race(t *testing.T) {
+	t.Run("Resolve", func(t *testing.T) {
+		rs := task.Race(task.Delay(time.Second), task.Resolve(2)).Result()
+		if rs != (task.Winner{Index: 1, Value: 2}) {
+			t.Error("错误的结果", rs)
+		}
+	})
+	t.Run("Reject", func(t *testing.T) {
+		r := task.Race(task.Delay(time.Second), task.Reject(errors.New("Reject"))).Wait()
+		if !r.IsFaulted() {
+			t.Error("错误的状态", r.State())
+		}
+	})
+}
+
+func Test_Any(t *testing.T) {
+	t.Run("Resolve", func(t *testing.T) {
+		rs := task.Any(
+			task.Reject(errors.New("Reject")),
+			task.Delay(time.Millisecond).Then(func(any) (any, error) {
+				return 2, nil
+			}),
+		).Result()
+		if rs != (task.Winner{Index: 1, Value: 2}) {
+			t.Error("错误的结果", rs)
+		}
+	})
+	t.Run("Reject", func(t *testing.T) {
+		rejectErr := errors.New("Reject")
+		err := task.Any(task.Reject(rejectErr), task.Cancel()).Error()
+		var agg *task.AggregateError
+		if !errors.As(err, &agg) || len(agg.Errors) != 2 || !errors.Is(err, rejectErr) {
+			t.Error("错误的结果", err)
+		}
+	})
+}
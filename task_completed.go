@@ -0,0 +1,79 @@
+package task
+
+import (
+	"context"
+	"sync"
+)
+
+type asCompleted struct {
+	mu     sync.Mutex
+	ch     chan Task
+	remain int
+	closed bool
+	stop   func() bool
+}
+
+func (ac *asCompleted) send(t Task) {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	if ac.closed {
+		return
+	}
+	ac.ch <- t
+	if ac.remain--; ac.remain == 0 {
+		ac.close()
+	}
+}
+
+// Close the channel, must be called while holding the lock
+func (ac *asCompleted) close() {
+	if !ac.closed {
+		ac.closed = true
+		close(ac.ch)
+		if ac.stop != nil {
+			ac.stop()
+		}
+	}
+}
+
+func asCompletedTask(tasks []Task, ctxs ...context.Context) <-chan Task {
+	ac := &asCompleted{ch: make(chan Task, len(tasks))}
+	for _, task := range tasks {
+		if task != nil {
+			ac.remain++
+		}
+	}
+	if ac.remain == 0 {
+		close(ac.ch)
+		return ac.ch
+	}
+	ctx := firstContext(ctxs, nil)
+	if ctx != nil && ctx.Done() != nil {
+		// stop early, the channel is closed when the context is canceled
+		ac.stop = context.AfterFunc(ctx, func() {
+			ac.mu.Lock()
+			ac.close()
+			ac.mu.Unlock()
+		})
+	}
+	sendFn := func(t Task) (interface{}, error) {
+		ac.send(t)
+		return nil, nil
+	}
+	for _, task := range tasks {
+		if task != nil {
+			task.Continue(sendFn, ctx)
+		}
+	}
+	return ac.ch
+}
+
+// Return a channel that yields each task as it is done, and is closed once all tasks are yielded
+func AsCompleted(tasks ...Task) <-chan Task {
+	return asCompletedTask(tasks)
+}
+func AsCompletedWithContext(tasks ...Task) func(ctxs ...context.Context) <-chan Task {
+	return func(ctxs ...context.Context) <-chan Task {
+		return asCompletedTask(tasks, ctxs...)
+	}
+}
@@ -0,0 +1,22 @@
+//go:build go1.23
+
+package task
+
+import (
+	"context"
+	"iter"
+)
+
+// Return an iterator that yields each task as it is done,
+// breaking the loop stops waiting for the remaining tasks.
+func AsCompletedSeq(tasks ...Task) iter.Seq[Task] {
+	return func(yield func(Task) bool) {
+		ctx, stop := context.WithCancel(context.Background())
+		defer stop()
+		for t := range asCompletedTask(tasks, ctx) {
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
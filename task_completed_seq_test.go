@@ -0,0 +1,24 @@
+//go:build go1.23
+
+package task_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pierre-primary/go-task"
+)
+
+func Test_AsCompletedSeq(t *testing.T) {
+	n := 0
+	for tk := range task.AsCompletedSeq(task.Resolve(1), task.Delay(time.Hour)) {
+		if tk.Result() != 1 {
+			t.Error("错误的结果", tk.Result())
+		}
+		n++
+		break
+	}
+	if n != 1 {
+		t.Error("错误的次数", n)
+	}
+}
@@ -0,0 +1,32 @@
+package task_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pierre-primary/go-task"
+)
+
+func Test_AsCompleted(t *testing.T) {
+	t.Run("Order", func(t *testing.T) {
+		slow := task.Delay(20 * time.Millisecond)
+		fast := task.Resolve(1)
+		var got []task.Task
+		for tk := range task.AsCompleted(slow, fast) {
+			got = append(got, tk)
+		}
+		if len(got) != 2 || got[0] != fast || got[1] != slow {
+			t.Error("错误的顺序", got)
+		}
+	})
+	t.Run("Context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		ch := task.AsCompletedWithContext(task.Resolve(1), task.Delay(time.Hour))(ctx)
+		<-ch
+		cancel()
+		if _, ok := <-ch; ok {
+			t.Error("通道未关闭")
+		}
+	})
+}
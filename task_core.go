@@ -1,6 +1,7 @@
 package task
 
 import (
+	"context"
 	"runtime"
 	"sync/atomic"
 )
@@ -22,6 +23,9 @@ const (
 	maskLock  uint32 = ((1 << 4) - 1) << 12 // 锁标记位掩码
 
 	/* option bit */
+	optLinkCancel uint32 = 0b0001 << 16 // 所有 Follower 取消时取消任务
+	optObserved   uint32 = 0b0010 << 16 // 任务的结果已被观察
+	optMetered    uint32 = 0b0100 << 16 // 任务计入指标
 	// nolint:unused
 	maskOptions uint32 = ((1 << 16) - 1) << 16 // 选项标记位掩码
 )
@@ -33,6 +37,16 @@ type (
 		data  interface{}
 		flws  *Follower
 		ch    chan struct{}
+		ext   *taskExt
+	}
+
+	// 任务扩展数据，仅在需要时分配，且在任务发布前完成初始化
+	taskExt struct {
+		stop   func(*TaskImpl) // 任务结束时调用，用于释放任务持有的资源（如取消任务的上下文）
+		refs   int32           // 未取消的 Follower 数量（optLinkCancel）
+		policy *Policy         // 任务的策略，为空时使用全局策略
+		info   *TaskInfo       // 任务的元数据
+		trace  *taskTrace      // 任务的追踪
 	}
 )
 
@@ -69,6 +83,16 @@ func setStateIfNot(task *TaskImpl, stateFlags, checkFlags uint32) bool {
 	}
 }
 
+// Set the option flags
+func setOption(task *TaskImpl, optionFlags uint32) {
+	for {
+		state := atomic.LoadUint32(&task.state)
+		if state&optionFlags == optionFlags || atomic.CompareAndSwapUint32(&task.state, state, state|optionFlags) {
+			return
+		}
+	}
+}
+
 // Lock with condition
 func lockStateIfNot(task *TaskImpl, lockFlags uint32, checkFlags uint32) bool {
 	for {
@@ -127,7 +151,93 @@ func wait(task *TaskImpl) {
 
 // Create an initial task
 func newTask() *TaskImpl {
-	return &TaskImpl{state: 0}
+	return publish(&TaskImpl{state: 0})
+}
+
+// Count and register the new task, must be called once its options and extension are set,
+// other goroutines may read the task afterwards.
+func publish(task *TaskImpl) *TaskImpl {
+	meterCreated(task)
+	track(task)
+	return task
+}
+
+// Get the extension of the task, allocate it if absent,
+// must be called before the task is published.
+func extOf(task *TaskImpl) *taskExt {
+	if task.ext == nil {
+		task.ext = &taskExt{}
+	}
+	return task.ext
+}
+
+// Create an initial task with the options of the context
+func newContextTask(ctx context.Context) *TaskImpl {
+	return publish(initContextTask(ctx))
+}
+
+// Create an initial task with the options of the context, without publishing it
+func initContextTask(ctx context.Context) *TaskImpl {
+	task := &TaskImpl{state: 0}
+	if ctx != nil {
+		if isLinkedContext(ctx) {
+			task.state |= optLinkCancel
+			extOf(task)
+		}
+		if policy := policyOf(ctx); policy != nil {
+			extOf(task).policy = policy
+		}
+	}
+	if info := contextInfo(ctx, nil); info != nil {
+		extOf(task).info = info
+	}
+	beginTrace(task, ctx, nil)
+	return task
+}
+
+// Create an initial follower task, inherit the options of the source task
+func newFollowerTask(task *TaskImpl, ctx context.Context) *TaskImpl {
+	flwTask := &TaskImpl{state: 0}
+	if ctx != nil {
+		if isLinkedContext(ctx) {
+			flwTask.state |= optLinkCancel
+			extOf(flwTask)
+		}
+		if policy := policyOf(ctx); policy != nil {
+			extOf(flwTask).policy = policy
+		}
+	}
+	if stateIs(task, optLinkCancel) {
+		flwTask.state |= optLinkCancel
+		extOf(flwTask)
+	}
+	var labels map[string]string
+	if task.ext != nil {
+		if task.ext.policy != nil && (flwTask.ext == nil || flwTask.ext.policy == nil) {
+			extOf(flwTask).policy = task.ext.policy
+		}
+		if task.ext.info != nil {
+			labels = task.ext.info.Labels
+		}
+	}
+	if info := contextInfo(ctx, labels); info != nil {
+		extOf(flwTask).info = info
+	}
+	beginTrace(flwTask, ctx, task)
+	return publish(flwTask)
+}
+
+// Create an initial task with a context that is canceled when the task is done
+func newCancelableTask(ctx context.Context) (*TaskImpl, context.Context) {
+	task := initContextTask(ctx)
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, stop := context.WithCancel(ctx)
+	extOf(task).stop = func(*TaskImpl) {
+		stop()
+	}
+	return publish(task), ctx
 }
 
 // Create a done task
@@ -135,8 +245,8 @@ func newDoneTask(flags uint32, data interface{}) *TaskImpl {
 	return &TaskImpl{state: flags, data: data, ch: closedChan}
 }
 
-// Terminate task
-func terminate(task *TaskImpl, state uint32, data interface{}) {
+// Terminate task, report whether the task is terminated by this call
+func terminate(task *TaskImpl, state uint32, data interface{}) bool {
 	// check and lock
 	if lockStateIfNot(task, lockState, checkDone) {
 		// temporarily pending time-consuming operations
@@ -145,15 +255,28 @@ func terminate(task *TaskImpl, state uint32, data interface{}) {
 		task.data = data
 		task.ch = closedChan
 
+		// count before the waiters are released
+		meterSettled(task, state)
+
 		// unlock
 		unlockStateAndSet(task, lockState, state)
 
 		// handle time-consuming operations
+		endTrace(task, state, data)
 		if tempCh != nil {
 			close(tempCh)
 		}
+		untrack(task)
+		if task.ext != nil && task.ext.stop != nil {
+			task.ext.stop(task)
+		}
+		if state == flagFailed {
+			watchUnobserved(task)
+		}
 		wakeAllFollower(task)
+		return true
 	}
+	return false
 }
 
 // Resolve task
@@ -166,16 +289,10 @@ func resolve(task *TaskImpl, result interface{}) {
 				internalPanicForce("A task cannot be resolved with itself.")
 				return
 			}
+			trackSub(task, sub)
 			sub.Wait()
 			// copy the result
-			switch sub.State() {
-			case STATE_COMPLETED:
-				resolve(task, sub.Result())
-			case STATE_CANCELED:
-				cancel(task, sub.Error())
-			default:
-				reject(task, sub.Error())
-			}
+			adopt(task, sub)
 		}
 	} else {
 		terminate(task, flagCompleted, result)
@@ -186,15 +303,28 @@ func resolve(task *TaskImpl, result interface{}) {
 func reject(task *TaskImpl, msg interface{}) {
 	switch v := msg.(type) {
 	case *ForcePanic:
-		panic(v.msg)
+		handleForcePanic(task, v)
 	case error:
-		terminate(task, flagFailed, v)
+		terminate(task, flagFailed, wrapError(task, v))
 	default:
-		terminate(task, flagFailed, toError(msg))
+		terminate(task, flagFailed, wrapError(task, toError(msg)))
 	}
 }
 
 // Cancel task
-func cancel(task *TaskImpl, err error) {
-	terminate(task, flagCanceled, err)
+func cancel(task *TaskImpl, err error) bool {
+	return terminate(task, flagCanceled, err)
+}
+
+// Settle task with the outcome of a done target,
+// the error is passed as is, it is not produced by the task.
+func adopt(task *TaskImpl, target Task) {
+	switch target.State() {
+	case STATE_COMPLETED:
+		resolve(task, target.Result())
+	case STATE_CANCELED:
+		cancel(task, target.Error())
+	default:
+		terminate(task, flagFailed, target.Error())
+	}
 }
@@ -20,6 +20,8 @@ type (
 	ContinueFunc = func(Task) (interface{}, error)
 	ThenFunc     = func(interface{}) (interface{}, error)
 	CatchFunc    = func(error) (interface{}, error)
+	CancelFunc   = func(error) (interface{}, error)
+	FinallyFunc  = func()
 )
 
 // 任务接口定义
@@ -30,6 +32,10 @@ type Task interface {
 	ThenAwait(ThenFunc, ...context.Context) Task
 	Catch(CatchFunc, ...context.Context) Task
 	CatchAwait(CatchFunc, ...context.Context) Task
+	OnCancel(CancelFunc, ...context.Context) Task
+	OnCancelAwait(CancelFunc, ...context.Context) Task
+	Finally(FinallyFunc, ...context.Context) Task
+	FinallyAwait(FinallyFunc, ...context.Context) Task
 	Done() chan struct{}
 	Wait(ctxs ...context.Context) Task
 	WaitTimeout(time.Duration, ...context.Context) Task
@@ -3,6 +3,7 @@ package task
 import (
 	"errors"
 	"fmt"
+	"runtime/debug"
 )
 
 var canceledError = errors.New("Task canceled")
@@ -31,3 +32,46 @@ func PanicForce(a interface{}) {
 func internalPanicForce(msg interface{}) {
 	panic(&ForcePanic{msg: fmt.Sprintf("Task: %v", msg)})
 }
+
+// 任务函数 panic 时任务失败的错误
+//
+// 携带 recover 得到的原始值以及 panic 处的调用栈，可通过 errors.As 从 Task.Error() 中获取：
+//
+//	var pe *task.PanicError
+//	if errors.As(t.Error(), &pe) {
+//		log.Printf("%v\n%s", pe.Value, pe.Stack)
+//	}
+type PanicError struct {
+	Value interface{} // recover 得到的原始值
+	Stack []byte      // panic 处的调用栈
+	Info  TaskInfo    // panic 的任务的元数据
+}
+
+func (e *PanicError) Error() string {
+	return toString(e.Value)
+}
+
+// Unwrap the original value if it is an error
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Wrap the recovered value, must be called in the deferred function which recovered.
+func recovered(r interface{}) interface{} {
+	if _, ok := r.(*ForcePanic); ok {
+		return r
+	}
+	return &PanicError{Value: r, Stack: debug.Stack()}
+}
+
+// Wrap the value recovered in the body of the task, attach the metadata of the task.
+func recoveredIn(task *TaskImpl, r interface{}) interface{} {
+	v := recovered(r)
+	if pe, ok := v.(*PanicError); ok {
+		if info := infoOf(task); info != nil {
+			pe.Info = *info
+		}
+	}
+	return v
+}
@@ -0,0 +1,239 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// 执行器接口定义
+//
+// 执行器负责调度 Run / Start / Delay 的任务体以及 Then / Catch / Continue 的回调，
+// 返回非空错误表示 fn 不会被执行，对应的任务将以该错误失败。
+type Executor interface {
+	Execute(fn func()) error
+}
+
+// 执行器适配函数
+type ExecutorFunc func(fn func()) error
+
+func (e ExecutorFunc) Execute(fn func()) error {
+	return e(fn)
+}
+
+var (
+	// 每次调度启动一个新的 goroutine（默认行为）
+	GoExecutor Executor = ExecutorFunc(func(fn func()) error {
+		spawn(fn)
+		return nil
+	})
+	// 在调度者的 goroutine 中同步执行
+	InlineExecutor Executor = ExecutorFunc(func(fn func()) error {
+		fn()
+		return nil
+	})
+)
+
+var poolClosedError = errors.New("Task: pool closed")
+
+func PoolClosed() error {
+	return poolClosedError
+}
+
+// ------------------------------------------------------------------------------------------------------
+/* Executor Select */
+
+type (
+	executorKey    struct{}
+	executorHolder struct{ executor Executor }
+)
+
+var defaultExecutor atomic.Value
+
+func init() {
+	defaultExecutor.Store(executorHolder{GoExecutor})
+}
+
+// Set the executor used when the context does not specify one
+func SetDefaultExecutor(e Executor) {
+	if e == nil {
+		e = GoExecutor
+	}
+	defaultExecutor.Store(executorHolder{e})
+}
+
+// Get the default executor
+func DefaultExecutor() Executor {
+	return defaultExecutor.Load().(executorHolder).executor
+}
+
+// Return a context that schedules the tasks created with it on the executor
+func WithExecutor(ctx context.Context, e Executor) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, executorKey{}, e)
+}
+
+// Get the executor of the context
+func executorOf(ctx context.Context) Executor {
+	if ctx != nil {
+		if e, ok := ctx.Value(executorKey{}).(Executor); ok && e != nil {
+			return e
+		}
+	}
+	return DefaultExecutor()
+}
+
+// ------------------------------------------------------------------------------------------------------
+/* Pool */
+
+type QueuePolicy = uint8
+
+// 队列已满时的策略
+const (
+	POLICY_BLOCK       QueuePolicy = 0 // 阻塞等待队列空位；调度 Follower 时不阻塞，在调度者的 goroutine 中执行
+	POLICY_ABORT       QueuePolicy = 1 // 立即失败，返回 QueueFullError
+	POLICY_CALLER_RUNS QueuePolicy = 2 // 在调度者的 goroutine 中执行
+)
+
+// 队列已满错误
+type QueueFullError struct {
+	Capacity int
+}
+
+func (e *QueueFullError) Error() string {
+	return fmt.Sprintf("Task: pool queue is full (capacity %d)", e.Capacity)
+}
+
+// 工作池选项
+type PoolOption func(*Pool)
+
+// Limit the number of queued functions, 0 means unlimited
+func WithQueueSize(size int) PoolOption {
+	return func(pool *Pool) {
+		pool.capacity = size
+	}
+}
+
+// Set the policy applied when the queue is full
+func WithQueuePolicy(policy QueuePolicy) PoolOption {
+	return func(pool *Pool) {
+		pool.policy = policy
+	}
+}
+
+// 固定大小的工作池执行器
+type Pool struct {
+	mu       sync.Mutex
+	notEmpty sync.Cond
+	notFull  sync.Cond
+	queue    []func()
+	capacity int
+	policy   QueuePolicy
+	closed   bool
+	wg       sync.WaitGroup
+}
+
+// Create a pool with a fixed number of workers
+func NewPool(workers int, opts ...PoolOption) *Pool {
+	if workers <= 0 {
+		workers = 1
+	}
+	pool := &Pool{}
+	for _, opt := range opts {
+		opt(pool)
+	}
+	if pool.capacity < 0 {
+		pool.capacity = 0
+	}
+	pool.notEmpty.L = &pool.mu
+	pool.notFull.L = &pool.mu
+	pool.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		spawn(pool.work)
+	}
+	return pool
+}
+
+func (pool *Pool) isFull() bool {
+	return pool.capacity > 0 && len(pool.queue) >= pool.capacity
+}
+
+func (pool *Pool) work() {
+	defer pool.wg.Done()
+	for {
+		pool.mu.Lock()
+		for len(pool.queue) == 0 && !pool.closed {
+			pool.notEmpty.Wait()
+		}
+		if len(pool.queue) == 0 {
+			pool.mu.Unlock()
+			return
+		}
+		fn := pool.queue[0]
+		pool.queue[0] = nil
+		pool.queue = pool.queue[1:]
+		pool.mu.Unlock()
+		pool.notFull.Signal()
+		fn()
+	}
+}
+
+func (pool *Pool) Execute(fn func()) error {
+	return pool.execute(fn, pool.policy)
+}
+
+// Queue fn, apply the policy when the queue is full
+func (pool *Pool) execute(fn func(), policy QueuePolicy) error {
+	pool.mu.Lock()
+	for !pool.closed && pool.isFull() {
+		switch policy {
+		case POLICY_ABORT:
+			pool.mu.Unlock()
+			return &QueueFullError{Capacity: pool.capacity}
+		case POLICY_CALLER_RUNS:
+			pool.mu.Unlock()
+			fn()
+			return nil
+		default:
+			pool.notFull.Wait()
+		}
+	}
+	if pool.closed {
+		pool.mu.Unlock()
+		return poolClosedError
+	}
+	pool.queue = append(pool.queue, fn)
+	pool.mu.Unlock()
+	pool.notEmpty.Signal()
+	return nil
+}
+
+// Schedule a follower, a full pool with POLICY_BLOCK runs it in the caller instead of blocking:
+// the caller may be a worker of the pool settling the source task, waiting for its own queue deadlocks.
+func executeFollower(e Executor, fn func()) error {
+	if pool, ok := e.(*Pool); ok && pool.policy == POLICY_BLOCK {
+		return pool.execute(fn, POLICY_CALLER_RUNS)
+	}
+	return e.Execute(fn)
+}
+
+// Number of queued functions
+func (pool *Pool) Len() int {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	return len(pool.queue)
+}
+
+// Stop accepting new functions, and wait for the queued functions to finish
+func (pool *Pool) Close() {
+	pool.mu.Lock()
+	pool.closed = true
+	pool.mu.Unlock()
+	pool.notEmpty.Broadcast()
+	pool.notFull.Broadcast()
+	pool.wg.Wait()
+}
@@ -0,0 +1,124 @@
+package task_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pierre-primary/go-task"
+)
+
+func Test_Executor(t *testing.T) {
+	t.Run("Inline", func(t *testing.T) {
+		ctx := task.WithExecutor(context.Background(), task.InlineExecutor)
+		tk := task.Run(func() (any, error) {
+			return 1, nil
+		}, ctx)
+		if !tk.IsDone() {
+			t.Error("错误的状态", tk.State())
+		}
+	})
+	t.Run("Pool", func(t *testing.T) {
+		pool := task.NewPool(2)
+		ctx := task.WithExecutor(context.Background(), pool)
+		var n int32
+		tasks := make([]task.Task, 100)
+		for i := range tasks {
+			tasks[i] = task.Run(func() (any, error) {
+				return atomic.AddInt32(&n, 1), nil
+			}, ctx).Then(func(rs any) (any, error) {
+				return rs, nil
+			}, ctx)
+		}
+		task.WaitAll(tasks...)
+		pool.Close()
+		if n != 100 {
+			t.Error("错误的结果", n)
+		}
+		if err := task.Run(func() (any, error) {
+			return nil, nil
+		}, ctx).Error(); err != task.PoolClosed() {
+			t.Error("错误的结果", err)
+		}
+	})
+}
+
+func Test_PoolPolicy(t *testing.T) {
+	block := make(chan struct{})
+	run := func() (any, error) {
+		<-block
+		return nil, nil
+	}
+	t.Run("Abort", func(t *testing.T) {
+		pool := task.NewPool(1, task.WithQueueSize(1), task.WithQueuePolicy(task.POLICY_ABORT))
+		defer pool.Close()
+		ctx := task.WithExecutor(context.Background(), pool)
+		started := make(chan struct{})
+		first := task.Run(func() (any, error) {
+			close(started)
+			return run()
+		}, ctx)
+		<-started
+		second := task.Run(run, ctx)
+		err := task.Run(run, ctx).Error()
+		var full *task.QueueFullError
+		if !errors.As(err, &full) || full.Capacity != 1 {
+			t.Error("错误的结果", err)
+		}
+		close(block)
+		task.WaitAll(first, second)
+	})
+	t.Run("CallerRuns", func(t *testing.T) {
+		pool := task.NewPool(1, task.WithQueueSize(1), task.WithQueuePolicy(task.POLICY_CALLER_RUNS))
+		defer pool.Close()
+		ctx := task.WithExecutor(context.Background(), pool)
+		hold := make(chan struct{})
+		started := make(chan struct{})
+		first := task.Run(func() (any, error) {
+			close(started)
+			<-hold
+			return nil, nil
+		}, ctx)
+		<-started
+		second := task.Run(func() (any, error) {
+			<-hold
+			return nil, nil
+		}, ctx)
+		third := task.Run(func() (any, error) {
+			return 3, nil
+		}, ctx)
+		if !third.IsDone() || third.Result() != 3 {
+			t.Error("错误的状态", third.State())
+		}
+		close(hold)
+		task.WaitAll(first, second)
+	})
+	t.Run("BlockFromWorker", func(t *testing.T) {
+		pool := task.NewPool(1, task.WithQueueSize(1))
+		defer pool.Close()
+		ctx := task.WithExecutor(context.Background(), pool)
+		hold := make(chan struct{})
+		started := make(chan struct{})
+		first := task.Run(func() (any, error) {
+			close(started)
+			<-hold
+			return nil, nil
+		}, ctx)
+		<-started
+		// the follower is scheduled by the worker while the queue is full, and runs in the worker
+		then := first.Then(func(any) (any, error) {
+			return 1, nil
+		}, ctx)
+		second := task.Run(func() (any, error) {
+			return 2, nil
+		}, ctx)
+		close(hold)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if then.Wait(ctx).Result() != 1 || second.Wait(ctx).Result() != 2 {
+			t.Fatal("工作者阻塞在自己的队列上", then.State(), second.State())
+		}
+	})
+}
@@ -2,6 +2,7 @@ package task
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
@@ -64,7 +65,7 @@ func (body taskCaller) Call(task *TaskImpl) {
 
 /* New */
 func New() (Task, ResolveFunc, RejectFunc) {
-	task := newTask()
+	task := newContextTask(nil)
 	return task, func(result interface{}) {
 			resolve(task, result)
 		}, func(err error) {
@@ -112,25 +113,53 @@ func Run(fn RunFunc, ctxs ...context.Context) Task {
 	return newStarter(runCaller(fn), ctx)
 }
 
+/* RunContext */
+type RunContextFunc = func(context.Context) (interface{}, error)
+type runContextCaller struct {
+	fn  RunContextFunc
+	ctx context.Context
+}
+
+func (body *runContextCaller) Call(task *TaskImpl) {
+	rs, err := body.fn(body.ctx)
+	switch {
+	case err == nil:
+		resolve(task, rs)
+	case body.ctx.Err() != nil && errors.Is(err, body.ctx.Err()):
+		cancel(task, err)
+	default:
+		reject(task, err)
+	}
+}
+
+// Run fn with a context that is canceled when the task is canceled (or otherwise done),
+// or when the given context is canceled.
+func RunContext(fn RunContextFunc, ctxs ...context.Context) Task {
+	ctx := firstContext(ctxs, nil)
+	if ctx != nil && isCanceledContext(ctx) {
+		return Cancel(ctx.Err())
+	}
+	task, taskCtx := newCancelableTask(ctx)
+	return startTask(task, &runContextCaller{fn: fn, ctx: taskCtx}, ctx)
+}
+
 /* Delay */
 func Delay(d time.Duration, ctxs ...context.Context) Task {
 	ctx := firstContext(ctxs, nil)
-	if ctx == nil {
-		ctx = context.TODO()
-	} else if isCanceledContext(ctx) {
+	if ctx != nil && isCanceledContext(ctx) {
 		return Cancel(ctx.Err())
 	}
+	task, taskCtx := newCancelableTask(ctx)
 	var fn taskCaller = func(task *TaskImpl) {
-		timer := time.NewTimer(d)
-		defer timer.Stop()
-		select {
-		case <-timer.C:
+		timer := clockOf(ctx).AfterFunc(d, func() {
 			resolve(task, nil)
-		case <-ctx.Done():
-			cancel(task, ctx.Err())
-		}
+		})
+		context.AfterFunc(taskCtx, func() {
+			timer.Stop()
+			cancel(task, taskCtx.Err())
+		})
 	}
-	return newStarter(fn, ctx)
+	return startTask(task, fn, ctx)
 }
 
 func waitAllTask(tasks []Task, ctxs ...context.Context) {
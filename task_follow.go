@@ -3,6 +3,7 @@ package task
 import (
 	"context"
 	"sync"
+	"time"
 )
 
 type (
@@ -11,6 +12,7 @@ type (
 		ctx    context.Context
 		caller FollowCaller
 		next   *Follower
+		queued time.Time
 	}
 
 	FollowCaller interface {
@@ -30,6 +32,7 @@ func (flw *Follower) release() {
 	flw.caller = nil
 	flw.ctx = nil
 	flw.next = nil
+	flw.queued = time.Time{}
 	flwPool.Put(flw)
 }
 
@@ -45,6 +48,7 @@ func assignFollower(task *TaskImpl, caller FollowCaller, ctx context.Context) *F
 // Wake up all follower
 func wakeAllFollower(target *TaskImpl) {
 	flw := target.flws
+	target.flws = nil
 	for flw != nil {
 		next := flw.next
 		flw.next = nil
@@ -60,13 +64,15 @@ func asyncExecFollower(flw *Follower, target Task, syncCheckConetxt bool) {
 		cancel(flw.task, flw.ctx.Err())
 		return
 	}
-	go func() {
+	flw.queued = meterStart()
+	err := executeFollower(executorOf(flw.ctx), func() {
 		// small closure way
 		// donot modify closure variable (flw, target),
 		// avoid additional memory allocation.
 		task := flw.task
 		caller := flw.caller
 		ctx := flw.ctx
+		meterQueued(flw.queued)
 		flw.release()
 
 		// async check context is canceled
@@ -75,23 +81,48 @@ func asyncExecFollower(flw *Follower, target Task, syncCheckConetxt bool) {
 			return
 		}
 
-		syncExecFollower(task, caller, target)
-	}()
+		if hasLabels(task) {
+			doLabeled(task, func(context.Context) {
+				syncExecFollower(task, caller, target, ctx)
+			})
+			return
+		}
+		syncExecFollower(task, caller, target, ctx)
+	})
+	// schedule failed
+	if err != nil {
+		task := flw.task
+		flw.release()
+		reject(task, err)
+	}
 }
 
 // Synchronous execute follower task
-func syncExecFollower(task *TaskImpl, caller FollowCaller, target Task) {
+func syncExecFollower(task *TaskImpl, caller FollowCaller, target Task, ctx context.Context) {
+	// canceled before executed
+	if stateIs(task, checkDone) {
+		return
+	}
+	// start timing
+	stopTimeout := armTimeout(task, ctx)
 	// safe exit
 	done := false
 	defer func() {
 		if r := recover(); r != nil {
-			reject(task, r)
+			reject(task, recoveredIn(task, r))
 		} else if !done {
 			resolve(task, nil)
 		}
+		if stopTimeout != nil {
+			stopTimeout()
+		}
 	}()
 	// try call
-	if ok, rs, err := caller.TryCall(target); ok {
+	if span := spanOf(task); span != nil {
+		span.Follow(target)
+	}
+	ok, rs, err := tryCall(caller, target)
+	if ok {
 		// can handle
 		if err == nil {
 			resolve(task, rs)
@@ -100,21 +131,20 @@ func syncExecFollower(task *TaskImpl, caller FollowCaller, target Task) {
 		}
 	} else {
 		// canot handle, passing the result
-		switch target.State() {
-		case STATE_COMPLETED:
-			resolve(task, target.Result())
-		case STATE_CANCELED:
-			cancel(task, target.Error())
-		default:
-			reject(task, target.Error())
-		}
+		adopt(task, target)
 	}
 	done = true
 }
 
+// Call the follower, timing it even if it panics
+func tryCall(caller FollowCaller, target Task) (bool, interface{}, error) {
+	defer meterRun(meterStart())
+	return caller.TryCall(target)
+}
+
 // Create a sync follower task, sync wait and execute
 func newSyncFollower(task *TaskImpl, caller FollowCaller, ctx context.Context) *TaskImpl {
-	flwTask := newTask()
+	flwTask := newFollowerTask(task, ctx)
 	if ctx == nil {
 		ctx = context.TODO()
 	}
@@ -124,18 +154,21 @@ func newSyncFollower(task *TaskImpl, caller FollowCaller, ctx context.Context) *
 		return flwTask
 	case <-done(task):
 	}
-	syncExecFollower(flwTask, caller, task)
+	syncExecFollower(flwTask, caller, task, ctx)
 	return flwTask
 }
 
 // Create a async follower task
 func newAsyncFollower(task *TaskImpl, caller FollowCaller, ctx context.Context) *TaskImpl {
-	flwTask := newTask()
+	flwTask := newFollowerTask(task, ctx)
 	flw := assignFollower(flwTask, caller, ctx)
 	// try join in follower linked
 	if lockStateIfNot(task, lockFlws, checkDone) {
 		flw.next = task.flws
 		task.flws = flw
+		if stateIs(task, optLinkCancel) {
+			linkFollower(task, flwTask, ctx)
+		}
 		unlockStateAndSet(task, lockFlws, 0)
 		return flwTask
 	}
@@ -0,0 +1,235 @@
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 任务等待图
+//
+// 由登记表（EnableTracking）中未结束的任务构成：Follower 指向其源任务（follows），
+// 以子任务解析、等待子任务结束的任务指向子任务（waits）。
+// 边引用的未登记任务也作为节点列出（Tracked 为 false）。
+
+// 等待图的输出格式
+type GraphFormat = uint8
+
+const (
+	GRAPH_TEXT GraphFormat = 0 // 文本
+	GRAPH_JSON GraphFormat = 1 // JSON
+	GRAPH_DOT  GraphFormat = 2 // Graphviz DOT
+)
+
+// 边的类型
+const (
+	EDGE_FOLLOWS = "follows" // Follower 等待源任务
+	EDGE_WAITS   = "waits"   // 任务等待子任务
+)
+
+// 等待图节点
+type GraphNode struct {
+	ID      string            `json:"id"`
+	Name    string            `json:"name,omitempty"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Site    string            `json:"site,omitempty"`
+	State   string            `json:"state"`
+	Created time.Time         `json:"created"`
+	Tracked bool              `json:"tracked"` // 是否在登记表中
+}
+
+// 等待图的边，From 等待 To
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Kind string `json:"kind"`
+}
+
+// 等待图
+type Graph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+// Return the wait graph of the registered tasks that are not done
+func TaskGraph() Graph {
+	type entry struct {
+		task   *TaskImpl
+		record taskRecord
+	}
+	registry.mu.Lock()
+	entries := make([]entry, 0, len(registry.tasks))
+	for task, record := range registry.tasks {
+		entries = append(entries, entry{task, *record})
+	}
+	registry.mu.Unlock()
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].record.id < entries[j].record.id
+	})
+
+	g := Graph{Nodes: make([]GraphNode, 0, len(entries))}
+	ids := make(map[Task]string, len(entries))
+	for _, e := range entries {
+		id := strconv.FormatUint(e.record.id, 10)
+		ids[e.task] = id
+		node := graphNode(e.task, id)
+		node.Created = e.record.created
+		node.Tracked = true
+		if node.Site == "" {
+			node.Site = e.record.site
+		}
+		g.Nodes = append(g.Nodes, node)
+	}
+	// reference a task, add it as an untracked node if it is not registered
+	ref := func(t Task) string {
+		if impl := implOf(t); impl != nil {
+			t = impl
+		}
+		if id, ok := ids[t]; ok {
+			return id
+		}
+		id := fmt.Sprintf("%p", t)
+		ids[t] = id
+		g.Nodes = append(g.Nodes, graphNode(t, id))
+		return id
+	}
+	for _, e := range entries {
+		from := ids[e.task]
+		for _, flwTask := range followersOf(e.task) {
+			g.Edges = append(g.Edges, GraphEdge{From: ref(flwTask), To: from, Kind: EDGE_FOLLOWS})
+		}
+		if e.record.sub != nil {
+			g.Edges = append(g.Edges, GraphEdge{From: from, To: ref(e.record.sub), Kind: EDGE_WAITS})
+		}
+	}
+	return g
+}
+
+// Get the underlying task implementation, nil if unknown
+func implOf(t Task) *TaskImpl {
+	switch v := t.(type) {
+	case *TaskImpl:
+		return v
+	case *Scope:
+		return v.TaskImpl
+	case interface{ Untyped() Task }:
+		impl, _ := v.Untyped().(*TaskImpl)
+		return impl
+	}
+	return nil
+}
+
+func graphNode(t Task, id string) GraphNode {
+	var info TaskInfo
+	if impl := implOf(t); impl != nil {
+		info = impl.Info()
+	}
+	return GraphNode{
+		ID:     id,
+		Name:   info.Name,
+		Labels: info.Labels,
+		Site:   info.Site,
+		State:  stateName(t.State()),
+	}
+}
+
+func stateName(state TaskState) string {
+	switch state {
+	case STATE_COMPLETED:
+		return "completed"
+	case STATE_FAULTED:
+		return "faulted"
+	case STATE_CANCELED:
+		return "canceled"
+	default:
+		return "pending"
+	}
+}
+
+// Write the wait graph of the registered tasks in the format,
+// tracking must be enabled (EnableTracking) before the tasks are created.
+func DumpGraph(w io.Writer, format GraphFormat) error {
+	g := TaskGraph()
+	switch format {
+	case GRAPH_TEXT:
+		return g.writeText(w)
+	case GRAPH_JSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(g)
+	case GRAPH_DOT:
+		return g.writeDot(w)
+	default:
+		return fmt.Errorf("Task: unknown graph format %d", format)
+	}
+}
+
+// Describe the node in one line
+func (node *GraphNode) describe() string {
+	var b strings.Builder
+	b.WriteString("#")
+	b.WriteString(node.ID)
+	b.WriteString(" [")
+	b.WriteString(node.State)
+	b.WriteString("]")
+	info := TaskInfo{Name: node.Name, Labels: node.Labels, Site: node.Site}
+	if s := strings.TrimPrefix(info.String(), "Task"); s != "" {
+		b.WriteString(s)
+	}
+	if !node.Tracked {
+		b.WriteString(" untracked")
+	}
+	return b.String()
+}
+
+func (g *Graph) writeText(w io.Writer) error {
+	var b strings.Builder
+	if !IsTracking() {
+		b.WriteString("# tracking is disabled, see task.EnableTracking\n")
+	}
+	out := make(map[string][]GraphEdge, len(g.Edges))
+	for _, edge := range g.Edges {
+		out[edge.From] = append(out[edge.From], edge)
+	}
+	for i := range g.Nodes {
+		node := &g.Nodes[i]
+		b.WriteString(node.describe())
+		b.WriteString("\n")
+		for _, edge := range out[node.ID] {
+			fmt.Fprintf(&b, "\t%s #%s\n", edge.Kind, edge.To)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (g *Graph) writeDot(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph tasks {\n")
+	b.WriteString("\tnode [shape=box];\n")
+	for i := range g.Nodes {
+		node := &g.Nodes[i]
+		label := "#" + node.ID + " " + node.State
+		if node.Name != "" {
+			label += "\n" + node.Name
+		}
+		if node.Site != "" {
+			label += "\n" + node.Site
+		}
+		style := ""
+		if !node.Tracked {
+			style = ", style=dashed"
+		}
+		fmt.Fprintf(&b, "\t%q [label=%q%s];\n", node.ID, label, style)
+	}
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&b, "\t%q -> %q [label=%q];\n", edge.From, edge.To, edge.Kind)
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
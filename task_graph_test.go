@@ -0,0 +1,95 @@
+package task_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pierre-primary/go-task"
+)
+
+func Test_DumpGraph(t *testing.T) {
+	disable := task.EnableTracking()
+	defer disable()
+
+	named := func(name string) context.Context {
+		return task.WithName(context.Background(), name)
+	}
+	release := make(chan struct{})
+	sub := task.Run(func() (any, error) {
+		<-release
+		return nil, nil
+	}, named("sub"))
+	parent := task.Run(func() (any, error) {
+		return sub, nil
+	}, named("parent"))
+	flw := parent.Then(func(any) (any, error) {
+		return nil, nil
+	}, named("follower"))
+	defer func() {
+		close(release)
+		flw.Wait()
+	}()
+
+	var (
+		g     task.Graph
+		ids   map[string]string
+		edges map[task.GraphEdge]bool
+		buf   bytes.Buffer
+	)
+	// the parent waits for the sub task once its body returns
+	deadline := time.Now().Add(time.Second)
+	for {
+		g = task.TaskGraph()
+		ids = map[string]string{}
+		for _, node := range g.Nodes {
+			ids[node.Name] = node.ID
+		}
+		edges = map[task.GraphEdge]bool{}
+		for _, edge := range g.Edges {
+			edges[edge] = true
+		}
+		if edges[task.GraphEdge{From: ids["parent"], To: ids["sub"], Kind: task.EDGE_WAITS}] {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("缺少 waits 边", g.Edges)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if !edges[task.GraphEdge{From: ids["follower"], To: ids["parent"], Kind: task.EDGE_FOLLOWS}] {
+		t.Error("缺少 follows 边", g.Edges)
+	}
+
+	if err := task.DumpGraph(&buf, task.GRAPH_JSON); err != nil {
+		t.Fatal(err)
+	}
+	var decoded task.Graph
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil || len(decoded.Nodes) < 3 {
+		t.Error("错误的 JSON", err, buf.String())
+	}
+
+	buf.Reset()
+	if err := task.DumpGraph(&buf, task.GRAPH_TEXT); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "[pending] parent") || !strings.Contains(buf.String(), "\twaits #"+ids["sub"]) {
+		t.Error("错误的文本", buf.String())
+	}
+
+	buf.Reset()
+	if err := task.DumpGraph(&buf, task.GRAPH_DOT); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(buf.String(), "digraph tasks {") ||
+		!strings.Contains(buf.String(), `"`+ids["follower"]+`" -> "`+ids["parent"]+`" [label="follows"]`) {
+		t.Error("错误的 DOT", buf.String())
+	}
+
+	if err := task.DumpGraph(&buf, 99); err == nil {
+		t.Error("未知格式应返回错误")
+	}
+}
@@ -0,0 +1,145 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// 任务组
+//
+// 与 errgroup.Group 类似：Go 启动的任务共享一个派生上下文，第一个任务失败时该上下文被取消；
+// SetLimit 限制同时运行的任务数量；Wait 返回一个在所有任务结束后
+// 解析为按启动顺序排列的结果（[]interface{}），或以第一个错误失败的任务。
+type Group struct {
+	ctx   context.Context
+	stop  context.CancelFunc
+	mu    sync.Mutex
+	tasks []Task
+	sem   chan struct{}
+	err   error
+}
+
+// Create a group and its derived context
+func NewGroup(ctxs ...context.Context) (*Group, context.Context) {
+	ctx := firstContext(ctxs, context.Background())
+	ctx, stop := context.WithCancel(ctx)
+	return &Group{ctx: ctx, stop: stop}, ctx
+}
+
+// Limit the number of active tasks, n < 0 means no limit.
+// The limit must not be modified while any task in the group is active.
+func (group *Group) SetLimit(n int) {
+	if n < 0 {
+		group.sem = nil
+		return
+	}
+	if len(group.sem) != 0 {
+		panic(fmt.Errorf("Task: modify group limit while %d tasks are active", len(group.sem)))
+	}
+	group.sem = make(chan struct{}, n)
+}
+
+// Start a task in the group, block until a slot is available when the group is limited
+func (group *Group) Go(fn RunFunc) Task {
+	if group.sem != nil {
+		group.sem <- struct{}{}
+	}
+	return group.start(fn)
+}
+
+// Start a task in the group only if a slot is available
+func (group *Group) TryGo(fn RunFunc) (Task, bool) {
+	if group.sem != nil {
+		select {
+		case group.sem <- struct{}{}:
+		default:
+			return nil, false
+		}
+	}
+	return group.start(fn), true
+}
+
+func (group *Group) start(fn RunFunc) Task {
+	task := Run(fn, group.ctx)
+	group.mu.Lock()
+	group.tasks = append(group.tasks, task)
+	group.mu.Unlock()
+	observe(task, group.settle)
+	return task
+}
+
+// Release the slot
+func (group *Group) done() {
+	if group.sem != nil {
+		<-group.sem
+	}
+}
+
+func (group *Group) settle(task Task) {
+	group.done()
+	if task.IsCompleted() {
+		return
+	}
+	group.mu.Lock()
+	first := group.err == nil
+	if first {
+		group.err = task.Error()
+	}
+	group.mu.Unlock()
+	if first {
+		group.stop()
+	}
+}
+
+// Return a task that is done once all tasks started so far are done
+func (group *Group) Wait() Task {
+	group.mu.Lock()
+	tasks := append([]Task(nil), group.tasks...)
+	group.mu.Unlock()
+
+	task := newTask()
+	observe(AllSettled(tasks...), func(all Task) {
+		group.stop()
+		settled := all.Result().([]Result)
+		// settle of the failed task may not have run yet, fall back to the outcomes
+		group.mu.Lock()
+		err := group.err
+		group.mu.Unlock()
+		if err == nil {
+			err = firstError(settled)
+		}
+		if err != nil {
+			reject(task, err)
+			return
+		}
+		results := make([]interface{}, len(settled))
+		for i, rs := range settled {
+			results[i] = rs.Value
+		}
+		resolve(task, results)
+	})
+	return task
+}
+
+// Return the error of the first faulted outcome, or of the first canceled outcome if none faulted
+func firstError(settled []Result) error {
+	var canceled *Result
+	for i := range settled {
+		switch settled[i].State {
+		case STATE_FAULTED:
+			return settled[i].Err
+		case STATE_CANCELED:
+			if canceled == nil {
+				canceled = &settled[i]
+			}
+		}
+	}
+	if canceled != nil {
+		if canceled.Err == nil {
+			return canceledError
+		}
+		return canceled.Err
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package task_test
+
+import (
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pierre-primary/go-task"
+)
+
+func Test_Group(t *testing.T) {
+	t.Run("Limit", func(t *testing.T) {
+		group, _ := task.NewGroup()
+		group.SetLimit(2)
+		var active, peak int32
+		for i := 0; i < 6; i++ {
+			n := i
+			group.Go(func() (any, error) {
+				a := atomic.AddInt32(&active, 1)
+				for {
+					p := atomic.LoadInt32(&peak)
+					if a <= p || atomic.CompareAndSwapInt32(&peak, p, a) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond)
+				atomic.AddInt32(&active, -1)
+				return n, nil
+			})
+		}
+		rs, err := group.Wait().Return()
+		if err != nil || !reflect.DeepEqual(rs, []interface{}{0, 1, 2, 3, 4, 5}) {
+			t.Error("错误的结果", rs, err)
+		}
+		if peak > 2 {
+			t.Error("超出并发限制", peak)
+		}
+	})
+	t.Run("FirstError", func(t *testing.T) {
+		group, ctx := task.NewGroup()
+		failErr := errors.New("Fail")
+		group.Go(func() (any, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+		group.Go(func() (any, error) {
+			return nil, failErr
+		})
+		if err := group.Wait().Error(); err != failErr {
+			t.Error("错误的结果", err)
+		}
+	})
+	t.Run("FailedMember", func(t *testing.T) {
+		failErr := errors.New("Fail")
+		for i := 0; i < 2000; i++ {
+			group, _ := task.NewGroup()
+			group.Go(func() (any, error) {
+				return nil, failErr
+			})
+			if err := group.Wait().Error(); err != failErr {
+				t.Fatal("错误的结果", i, err)
+			}
+		}
+	})
+	t.Run("SetLimitActive", func(t *testing.T) {
+		group, _ := task.NewGroup()
+		group.SetLimit(1)
+		release := make(chan struct{})
+		group.Go(func() (any, error) {
+			<-release
+			return nil, nil
+		})
+		defer func() {
+			close(release)
+			group.Wait().Wait()
+			if _, ok := recover().(error); !ok {
+				t.Error("应以 error panic")
+			}
+		}()
+		group.SetLimit(2)
+	})
+}
@@ -9,7 +9,12 @@ import (
 // 实现 Task 接口的方法
 
 func (task *TaskImpl) State() TaskState {
-	switch state(task) & maskState {
+	return stateOf(state(task))
+}
+
+// Convert the state bits to TaskState
+func stateOf(state uint32) TaskState {
+	switch state & maskState {
 	case flagCompleted:
 		return STATE_COMPLETED
 	case flagFailed:
@@ -39,6 +44,7 @@ func (task *TaskImpl) IsCanceled() bool {
 
 func (task *TaskImpl) Return() (interface{}, error) {
 	wait(task)
+	setOption(task, optObserved)
 	if stateIs(task, checkCompleted) {
 		return task.data, nil
 	}
@@ -56,6 +62,7 @@ func (task *TaskImpl) Result() interface{} {
 
 func (task *TaskImpl) Error() error {
 	wait(task)
+	setOption(task, optObserved)
 	if stateIs(task, checkCompleted) {
 		return nil
 	}
@@ -63,6 +70,32 @@ func (task *TaskImpl) Error() error {
 	return err
 }
 
+// Cancel the pending task, report whether the task is canceled by this call.
+// The context of a task created by RunContext is canceled as well,
+// and the followers of the task are canceled in turn.
+func (task *TaskImpl) Cancel(cause error) bool {
+	if cause == nil {
+		cause = canceledError
+	}
+	return cancel(task, cause)
+}
+
+// Cancel the task if it supports cancellation, report whether the task is canceled by this call
+func cancelTask(t Task, cause error) bool {
+	if c, ok := t.(interface{ Cancel(error) bool }); ok {
+		return c.Cancel(cause)
+	}
+	return false
+}
+
+// Return the metadata of the task, zero if the task has none
+func (task *TaskImpl) Info() TaskInfo {
+	if info := infoOf(task); info != nil {
+		return *info
+	}
+	return TaskInfo{}
+}
+
 func (task *TaskImpl) Done() chan struct{} {
 	return done(task)
 }
@@ -84,12 +117,12 @@ func (task *TaskImpl) WaitTimeout(d time.Duration, ctxs ...context.Context) Task
 		return task
 	}
 	ctx := firstContext(ctxs, context.TODO())
-	timer := time.NewTimer(d)
+	timer := clockOf(ctx).NewTimer(d)
 	defer timer.Stop()
 	select {
 	case <-done(task):
 	case <-ctx.Done():
-	case <-timer.C:
+	case <-timer.C():
 	}
 	return task
 }
@@ -177,3 +210,60 @@ func (task *TaskImpl) CatchAwait(fn CatchFunc, ctxs ...context.Context) Task {
 	}
 	return newSyncFollower(task, catchCaller(fn), ctx)
 }
+
+/* OnCancel */
+
+type cancelCaller CancelFunc
+
+func (body cancelCaller) TryCall(target Task) (bool, interface{}, error) {
+	if body == nil || !target.IsCanceled() {
+		return false, nil, nil
+	}
+	rs, err := body(target.Error())
+	return true, rs, err
+}
+
+func (task *TaskImpl) OnCancel(fn CancelFunc, ctxs ...context.Context) Task {
+	ctx := firstContext(ctxs, nil)
+	if ctx != nil && isCanceledContext(ctx) {
+		return Cancel(ctx.Err())
+	}
+	return newAsyncFollower(task, cancelCaller(fn), ctx)
+}
+
+func (task *TaskImpl) OnCancelAwait(fn CancelFunc, ctxs ...context.Context) Task {
+	ctx := firstContext(ctxs, nil)
+	if ctx != nil && isCanceledContext(ctx) {
+		return Cancel(ctx.Err())
+	}
+	return newSyncFollower(task, cancelCaller(fn), ctx)
+}
+
+/* Finally */
+
+type finallyCaller FinallyFunc
+
+// Call the body in any state, and pass the result through,
+// unless the body panics.
+func (body finallyCaller) TryCall(target Task) (bool, interface{}, error) {
+	if body != nil {
+		body()
+	}
+	return false, nil, nil
+}
+
+func (task *TaskImpl) Finally(fn FinallyFunc, ctxs ...context.Context) Task {
+	ctx := firstContext(ctxs, nil)
+	if ctx != nil && isCanceledContext(ctx) {
+		return Cancel(ctx.Err())
+	}
+	return newAsyncFollower(task, finallyCaller(fn), ctx)
+}
+
+func (task *TaskImpl) FinallyAwait(fn FinallyFunc, ctxs ...context.Context) Task {
+	ctx := firstContext(ctxs, nil)
+	if ctx != nil && isCanceledContext(ctx) {
+		return Cancel(ctx.Err())
+	}
+	return newSyncFollower(task, finallyCaller(fn), ctx)
+}
@@ -0,0 +1,202 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/pprof"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+// 任务元数据
+//
+// 通过 WithName / WithLabels 返回的上下文创建的任务携带名称和标签，并记录创建位置（file:line）；
+// Follower 继承源任务的标签。元数据可通过 Info 读取，会附加到任务的错误（TaskError, PanicError）上，
+// 并在执行任务函数时设置为 runtime/pprof 标签（名称的标签键为 "task"）。
+
+// 任务的元数据
+type TaskInfo struct {
+	Name   string            // 名称
+	Labels map[string]string // 标签，只读
+	Site   string            // 创建位置（file:line）
+}
+
+func (info TaskInfo) String() string {
+	var b strings.Builder
+	b.WriteString("Task")
+	if info.Name != "" {
+		b.WriteString(" ")
+		b.WriteString(info.Name)
+	}
+	if len(info.Labels) > 0 {
+		keys := make([]string, 0, len(info.Labels))
+		for k := range info.Labels {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		b.WriteString(" {")
+		for i, k := range keys {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(k)
+			b.WriteString("=")
+			b.WriteString(info.Labels[k])
+		}
+		b.WriteString("}")
+	}
+	if info.Site != "" {
+		b.WriteString(" (")
+		b.WriteString(info.Site)
+		b.WriteString(")")
+	}
+	return b.String()
+}
+
+type (
+	nameKey   struct{}
+	labelsKey struct{}
+)
+
+// Return a context that names the tasks created with it
+func WithName(ctx context.Context, name string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, nameKey{}, name)
+}
+
+// Return a context that labels the tasks created with it,
+// kv is a list of key/value pairs, merged with the labels of the parent context.
+func WithLabels(ctx context.Context, kv ...string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if len(kv)%2 != 0 {
+		panic(fmt.Errorf("Task: odd number of label arguments %d", len(kv)))
+	}
+	parent, _ := ctx.Value(labelsKey{}).(map[string]string)
+	labels := make(map[string]string, len(parent)+len(kv)/2)
+	for k, v := range parent {
+		labels[k] = v
+	}
+	for i := 0; i < len(kv); i += 2 {
+		labels[kv[i]] = kv[i+1]
+	}
+	return context.WithValue(ctx, labelsKey{}, labels)
+}
+
+var captureSite int32
+
+// Set whether to capture the creation site of every task,
+// by default only the tasks with a name or labels capture it.
+// The errors of the tasks without a name or labels are never wrapped in TaskError.
+func SetCaptureSite(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&captureSite, v)
+}
+
+// Build the metadata of a new task from the context, inherit is the labels of the source task.
+// Return nil if the task has no metadata.
+func contextInfo(ctx context.Context, inherit map[string]string) *TaskInfo {
+	var (
+		name   string
+		labels = inherit
+	)
+	if ctx != nil {
+		name, _ = ctx.Value(nameKey{}).(string)
+		if v, ok := ctx.Value(labelsKey{}).(map[string]string); ok {
+			labels = mergeLabels(inherit, v)
+		}
+	}
+	if name == "" && len(labels) == 0 && atomic.LoadInt32(&captureSite) == 0 {
+		return nil
+	}
+	return &TaskInfo{Name: name, Labels: labels, Site: callerSite(2)}
+}
+
+// Merge the labels, the latter wins, return one of them if the other is empty
+func mergeLabels(a, b map[string]string) map[string]string {
+	if len(a) == 0 {
+		return b
+	}
+	if len(b) == 0 {
+		return a
+	}
+	labels := make(map[string]string, len(a)+len(b))
+	for k, v := range a {
+		labels[k] = v
+	}
+	for k, v := range b {
+		labels[k] = v
+	}
+	return labels
+}
+
+// Get the metadata of the task, nil if absent
+func infoOf(task *TaskImpl) *TaskInfo {
+	if task.ext == nil {
+		return nil
+	}
+	return task.ext.info
+}
+
+// Check whether the task has a name or labels
+func hasLabels(task *TaskImpl) bool {
+	info := infoOf(task)
+	return info != nil && (info.Name != "" || len(info.Labels) > 0)
+}
+
+// Run fn with the name and labels of the task as pprof labels of the goroutine
+func doLabeled(task *TaskImpl, fn func(context.Context)) {
+	info := infoOf(task)
+	args := make([]string, 0, 2+len(info.Labels)*2)
+	if info.Name != "" {
+		args = append(args, "task", info.Name)
+	}
+	for k, v := range info.Labels {
+		args = append(args, k, v)
+	}
+	pprof.Do(context.Background(), pprof.Labels(args...), fn)
+}
+
+// 携带任务元数据的错误
+//
+// 有名称或标签的任务的任务函数或回调失败时，错误被包装为 TaskError，可通过 errors.As 获取；
+// 原始错误可通过 errors.Is / errors.As / Unwrap 获取。
+// 错误沿任务链传递时保留最初失败的任务的元数据。
+type TaskError struct {
+	Info TaskInfo // 失败的任务的元数据
+	Err  error    // 原始错误
+}
+
+func (e *TaskError) Error() string {
+	return e.Info.String() + ": " + toString(e.Err)
+}
+
+func (e *TaskError) Unwrap() error {
+	return e.Err
+}
+
+// Attach the metadata of the task to the error if the task has a name or labels,
+// the errors adopted from other tasks are not wrapped.
+func wrapError(task *TaskImpl, err error) error {
+	if !hasLabels(task) {
+		return err
+	}
+	info := infoOf(task)
+	var te *TaskError
+	if errors.As(err, &te) {
+		return err
+	}
+	var pe *PanicError
+	if errors.As(err, &pe) && pe.Info.Site != "" {
+		return err
+	}
+	return &TaskError{Info: *info, Err: err}
+}
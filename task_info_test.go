@@ -0,0 +1,131 @@
+package task_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"runtime/pprof"
+	"strings"
+	"testing"
+
+	"github.com/pierre-primary/go-task"
+)
+
+func Test_Info(t *testing.T) {
+	t.Run("Metadata", func(t *testing.T) {
+		ctx := task.WithLabels(task.WithName(context.Background(), "fetch"), "user", "42")
+		tk := task.Run(func() (any, error) {
+			return nil, nil
+		}, ctx)
+		info := tk.(*task.TaskImpl).Info()
+		if info.Name != "fetch" || info.Labels["user"] != "42" {
+			t.Error("错误的元数据", info)
+		}
+		if !strings.Contains(info.Site, "task_info_test.go:") {
+			t.Error("错误的创建位置", info.Site)
+		}
+		if info := task.Resolve().(*task.TaskImpl).Info(); info.Name != "" || info.Site != "" {
+			t.Error("不应有元数据", info)
+		}
+	})
+
+	t.Run("Inherit", func(t *testing.T) {
+		ctx := task.WithLabels(task.WithName(context.Background(), "fetch"), "user", "42")
+		src := task.Run(func() (any, error) {
+			return nil, nil
+		}, ctx)
+		flw := src.Then(func(any) (any, error) {
+			return nil, nil
+		}, task.WithLabels(context.Background(), "step", "parse"))
+		info := flw.(*task.TaskImpl).Info()
+		if info.Name != "" || info.Labels["user"] != "42" || info.Labels["step"] != "parse" {
+			t.Error("错误的元数据", info)
+		}
+		if src.(*task.TaskImpl).Info().Labels["step"] != "" {
+			t.Error("不应修改源任务的标签")
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		rejectErr := errors.New("Reject")
+		tk := task.Run(func() (any, error) {
+			return nil, rejectErr
+		}, task.WithName(context.Background(), "fetch"))
+		err := tk.Wait().Error()
+		var te *task.TaskError
+		if !errors.As(err, &te) || te.Info.Name != "fetch" || !errors.Is(err, rejectErr) {
+			t.Error("错误的结果", err)
+		}
+		// keep the metadata of the task that fails first
+		err = tk.Then(func(any) (any, error) {
+			return nil, nil
+		}, task.WithName(context.Background(), "next")).Wait().Error()
+		if !errors.As(err, &te) || te.Info.Name != "fetch" {
+			t.Error("错误的结果", err)
+		}
+	})
+
+	t.Run("PassThrough", func(t *testing.T) {
+		rejectErr := errors.New("Reject")
+		// the follower does not handle the error, it is passed as is
+		err := task.Reject(rejectErr).Then(func(any) (any, error) {
+			return nil, nil
+		}, task.WithName(context.Background(), "parse")).Error()
+		if err != rejectErr {
+			t.Error("错误的结果", err)
+		}
+	})
+
+	t.Run("OddLabels", func(t *testing.T) {
+		defer func() {
+			if _, ok := recover().(error); !ok {
+				t.Error("未 panic")
+			}
+		}()
+		task.WithLabels(context.Background(), "user")
+	})
+
+	t.Run("CaptureSite", func(t *testing.T) {
+		task.SetCaptureSite(true)
+		defer task.SetCaptureSite(false)
+		rejectErr := errors.New("Reject")
+		tk := task.Run(func() (any, error) {
+			return nil, rejectErr
+		})
+		// only named or labeled tasks wrap their errors
+		if err := tk.Wait().Error(); err != rejectErr {
+			t.Error("错误的结果", err)
+		}
+		if info := tk.(*task.TaskImpl).Info(); !strings.Contains(info.Site, "task_info_test.go:") {
+			t.Error("错误的创建位置", info.Site)
+		}
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		tk := task.Run(func() (any, error) {
+			panic("boom")
+		}, task.WithName(context.Background(), "fetch"))
+		var pe *task.PanicError
+		if err := tk.Wait().Error(); !errors.As(err, &pe) || pe.Info.Name != "fetch" {
+			t.Error("错误的结果", err)
+		}
+	})
+
+	t.Run("Pprof", func(t *testing.T) {
+		running := make(chan struct{})
+		release := make(chan struct{})
+		tk := task.Run(func() (any, error) {
+			close(running)
+			<-release
+			return nil, nil
+		}, task.WithLabels(task.WithName(context.Background(), "fetch"), "user", "42"))
+		<-running
+		var buf bytes.Buffer
+		pprof.Lookup("goroutine").WriteTo(&buf, 1)
+		close(release)
+		tk.Wait()
+		if !strings.Contains(buf.String(), `"task":"fetch"`) || !strings.Contains(buf.String(), `"user":"42"`) {
+			t.Error("未设置 pprof 标签")
+		}
+	})
+}
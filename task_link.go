@@ -0,0 +1,73 @@
+package task
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// 关联取消
+//
+// 使用 WithLinkedCancel 返回的上下文创建的任务（及其 Follower，逐级继承）开启关联取消：
+// 当任务在结束前注册的所有 Follower 均已取消时，任务本身也被取消，
+// 由 RunContext 创建的任务的上下文随之取消，从而停止无人等待的上游任务。
+
+type linkKey struct{}
+
+// Return a context that enables linked cancellation for the tasks created with it
+func WithLinkedCancel(ctx context.Context) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, linkKey{}, true)
+}
+
+// Check whether the context enables linked cancellation
+func isLinkedContext(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	linked, _ := ctx.Value(linkKey{}).(bool)
+	return linked
+}
+
+// Link the follower task to the source task,
+// must be called while holding lockFlws of the source task.
+func linkFollower(task *TaskImpl, flwTask *TaskImpl, ctx context.Context) {
+	atomic.AddInt32(&task.ext.refs, 1)
+
+	var unwatch atomic.Pointer[func() bool]
+	flwTask.ext.stop = func(flwTask *TaskImpl) {
+		if stateIs(flwTask, flagCanceled) {
+			err, _ := flwTask.data.(error)
+			unlinkFollower(task, err)
+		}
+		if stop := unwatch.Swap(nil); stop != nil {
+			(*stop)()
+		}
+	}
+	// eagerly cancel the follower task when its context is canceled
+	if ctx != nil && ctx.Done() != nil {
+		stop := context.AfterFunc(ctx, func() {
+			cancel(flwTask, ctx.Err())
+		})
+		unwatch.Store(&stop)
+		if stateIs(flwTask, checkDone) {
+			if stop := unwatch.Swap(nil); stop != nil {
+				(*stop)()
+			}
+		}
+	}
+}
+
+// Release a canceled follower, cancel the source task if no follower remains
+func unlinkFollower(task *TaskImpl, err error) {
+	if stateIs(task, checkDone) {
+		return
+	}
+	if atomic.AddInt32(&task.ext.refs, -1) == 0 {
+		if err == nil {
+			err = canceledError
+		}
+		cancel(task, err)
+	}
+}
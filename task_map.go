@@ -0,0 +1,178 @@
+package task
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// 并行映射选项
+type MapOption func(*mapConfig)
+
+type mapConfig struct {
+	limit      int
+	collectAll bool
+	ctx        context.Context
+}
+
+// Limit the number of items processed concurrently, 0 means no limit
+func MapLimit(n int) MapOption {
+	return func(cfg *mapConfig) {
+		cfg.limit = n
+	}
+}
+
+// Process all items even if some fail, and reject with an AggregateError of all errors.
+// By default the first error cancels the remaining items and rejects the task.
+func MapCollectAll() MapOption {
+	return func(cfg *mapConfig) {
+		cfg.collectAll = true
+	}
+}
+
+// Set the context, the remaining items are skipped and the task is canceled when it is canceled
+func MapContext(ctx context.Context) MapOption {
+	return func(cfg *mapConfig) {
+		cfg.ctx = ctx
+	}
+}
+
+type mapper struct {
+	task    *TaskImpl
+	ctx     context.Context
+	size    int
+	next    int32
+	workers int32
+	call    func(index int) error
+	errs    []error
+	failed  int32
+	result  func() interface{}
+	cfg     mapConfig
+}
+
+// Run a worker, the worker task is resolved once it returns
+func (m *mapper) work(worker *TaskImpl) {
+	defer m.finish()
+	m.process()
+	resolve(worker, nil)
+}
+
+// Process items until no item remains
+func (m *mapper) process() {
+	for !isCanceledContext(m.ctx) {
+		index := int(atomic.AddInt32(&m.next, 1) - 1)
+		if index >= m.size {
+			return
+		}
+		if err := m.callSafe(index); err != nil {
+			if !m.cfg.collectAll {
+				reject(m.task, err)
+				return
+			}
+			m.errs[index] = err
+			atomic.StoreInt32(&m.failed, 1)
+		}
+	}
+}
+
+func (m *mapper) callSafe(index int) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			pe, ok := recovered(r).(*PanicError)
+			if !ok {
+				panic(r)
+			}
+			err = pe
+		}
+	}()
+	return m.call(index)
+}
+
+// Settle the task when the last worker finishes
+func (m *mapper) finish() {
+	if atomic.AddInt32(&m.workers, -1) != 0 {
+		return
+	}
+	switch {
+	case stateIs(m.task, checkDone):
+	case isCanceledContext(m.ctx):
+		cancel(m.task, m.ctx.Err())
+	case atomic.LoadInt32(&m.failed) != 0:
+		errs := make([]error, 0, len(m.errs))
+		for _, err := range m.errs {
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+		reject(m.task, &AggregateError{Errors: errs})
+	default:
+		resolve(m.task, m.result())
+	}
+}
+
+// Start the workers of the mapping
+func startMapper(size int, call func(index int) error, result func() interface{}, opts []MapOption) *TaskImpl {
+	var cfg mapConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.ctx != nil && isCanceledContext(cfg.ctx) {
+		return Cancel(cfg.ctx.Err()).(*TaskImpl)
+	}
+	task, ctx := newCancelableTask(cfg.ctx)
+	if size == 0 {
+		resolve(task, result())
+		return task
+	}
+	workers := size
+	if cfg.limit > 0 && cfg.limit < workers {
+		workers = cfg.limit
+	}
+	m := &mapper{
+		task:    task,
+		ctx:     ctx,
+		size:    size,
+		workers: int32(workers),
+		call:    call,
+		result:  result,
+		cfg:     cfg,
+	}
+	if cfg.collectAll {
+		m.errs = make([]error, size)
+	}
+	// workers ignore the cancellation before started, m.work handles it
+	workerCtx := context.WithoutCancel(ctx)
+	for i := 0; i < workers; i++ {
+		worker := newStarter(taskCaller(m.work), workerCtx)
+		observe(worker, func(worker Task) {
+			// schedule failed or force panic
+			if worker.IsFaulted() {
+				reject(m.task, worker.Error())
+			}
+		})
+	}
+	return task
+}
+
+/* Map */
+
+// Call fn for each item concurrently, and resolve to the results in the order of items
+func Map(items []interface{}, fn func(interface{}) (interface{}, error), opts ...MapOption) Task {
+	results := make([]interface{}, len(items))
+	return startMapper(len(items), func(index int) (err error) {
+		results[index], err = fn(items[index])
+		return err
+	}, func() interface{} {
+		return results
+	}, opts)
+}
+
+// Typed version of Map, resolves to []U
+func MapOf[T, U any](items []T, fn func(T) (U, error), opts ...MapOption) TaskOf[[]U] {
+	results := make([]U, len(items))
+	return TaskOf[[]U]{startMapper(len(items), func(index int) (err error) {
+		results[index], err = fn(items[index])
+		return err
+	}, func() interface{} {
+		return results
+	}, opts)}
+}
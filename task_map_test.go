@@ -0,0 +1,80 @@
+package task_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pierre-primary/go-task"
+	"github.com/pierre-primary/go-task/tasktest"
+)
+
+func Test_Map(t *testing.T) {
+	t.Run("Resolve", func(t *testing.T) {
+		tasktest.VerifyNoPending(t)
+		var active, peak int32
+		rs, err := task.Map([]interface{}{1, 2, 3, 4, 5}, func(v any) (any, error) {
+			a := atomic.AddInt32(&active, 1)
+			defer atomic.AddInt32(&active, -1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if a <= p || atomic.CompareAndSwapInt32(&peak, p, a) {
+					break
+				}
+			}
+			return v.(int) * 2, nil
+		}, task.MapLimit(2)).Return()
+		if err != nil || !reflect.DeepEqual(rs, []interface{}{2, 4, 6, 8, 10}) {
+			t.Error("错误的结果", rs, err)
+		}
+		if peak > 2 {
+			t.Error("超出并发限制", peak)
+		}
+	})
+	t.Run("FailFast", func(t *testing.T) {
+		tasktest.VerifyNoPending(t)
+		failErr := errors.New("Fail")
+		err := task.Map([]interface{}{1, 2, 3}, func(v any) (any, error) {
+			if v == 2 {
+				return nil, failErr
+			}
+			return v, nil
+		}, task.MapLimit(1)).Error()
+		if err != failErr {
+			t.Error("错误的结果", err)
+		}
+	})
+	t.Run("CollectAll", func(t *testing.T) {
+		err := task.Map([]interface{}{1, 2, 3}, func(v any) (any, error) {
+			if v != 2 {
+				return nil, errors.New(strconv.Itoa(v.(int)))
+			}
+			return v, nil
+		}, task.MapCollectAll()).Error()
+		var agg *task.AggregateError
+		if !errors.As(err, &agg) || len(agg.Errors) != 2 {
+			t.Error("错误的结果", err)
+		}
+	})
+	t.Run("Cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		tk := task.Map([]interface{}{1, 2, 3}, func(v any) (any, error) {
+			cancel()
+			return v, nil
+		}, task.MapLimit(1), task.MapContext(ctx))
+		if tk.Wait(); !tk.IsCanceled() {
+			t.Error("错误的状态", tk.State())
+		}
+	})
+	t.Run("Typed", func(t *testing.T) {
+		rs, err := task.MapOf([]int{1, 2, 3}, func(v int) (string, error) {
+			return strconv.Itoa(v), nil
+		}).Return()
+		if err != nil || !reflect.DeepEqual(rs, []string{"1", "2", "3"}) {
+			t.Error("错误的结果", rs, err)
+		}
+	})
+}
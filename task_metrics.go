@@ -0,0 +1,186 @@
+package task
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 运行时指标
+//
+// 开启指标后（EnableMetrics），统计新创建任务的创建和结束数量（按结束状态）、
+// Follower 从源任务结束（或加入已结束的源任务）到开始执行的排队延迟、任务函数的执行时长，
+// 以及库启动的仍在运行的 goroutine 数量（GoExecutor, Pool 工作者等）。
+// 可通过 Stats 获取快照，通过 taskdebug.PublishExpvar 发布到 expvar。
+
+// 直方图的桶上界，最后一个桶统计超过所有上界的值
+var HistogramBounds = [...]time.Duration{
+	10 * time.Microsecond,
+	100 * time.Microsecond,
+	time.Millisecond,
+	10 * time.Millisecond,
+	100 * time.Millisecond,
+	time.Second,
+	10 * time.Second,
+}
+
+// 时长直方图快照
+type Histogram struct {
+	Counts [len(HistogramBounds) + 1]uint64 // 各桶的数量，Counts[i] 统计不超过 HistogramBounds[i] 的值
+	Count  uint64                           // 总数量
+	Sum    time.Duration                    // 总时长
+}
+
+// Return the mean duration, 0 if empty
+func (h Histogram) Mean() time.Duration {
+	if h.Count == 0 {
+		return 0
+	}
+	return h.Sum / time.Duration(h.Count)
+}
+
+// 指标快照
+type Metrics struct {
+	Created      uint64        // 创建的任务数量
+	Settled      [4]uint64     // 按 TaskState 索引的结束的任务数量
+	Pending      uint64        // 未结束的任务数量
+	Goroutines   int64         // 库启动的仍在运行的 goroutine 数量
+	QueueLatency Histogram     // Follower 的排队延迟
+	RunDuration  Histogram     // 任务函数的执行时长
+	Uptime       time.Duration // 开启指标的时长
+}
+
+type histogram struct {
+	counts [len(HistogramBounds) + 1]uint64
+	count  uint64
+	sum    int64
+}
+
+func (h *histogram) observe(d time.Duration) {
+	i := 0
+	for i < len(HistogramBounds) && d > HistogramBounds[i] {
+		i++
+	}
+	atomic.AddUint64(&h.counts[i], 1)
+	atomic.AddUint64(&h.count, 1)
+	atomic.AddInt64(&h.sum, int64(d))
+}
+
+func (h *histogram) snapshot() Histogram {
+	var s Histogram
+	for i := range h.counts {
+		s.Counts[i] = atomic.LoadUint64(&h.counts[i])
+	}
+	s.Count = atomic.LoadUint64(&h.count)
+	s.Sum = time.Duration(atomic.LoadInt64(&h.sum))
+	return s
+}
+
+var metrics struct {
+	// 64 位原子操作的字段在前，保证 32 位平台上的对齐
+	created      uint64
+	settled      [4]uint64
+	goroutines   int64
+	queueLatency histogram
+	runDuration  histogram
+	mu           sync.Mutex
+	since        time.Time // 首次开启指标的时间
+	enabled      int32     // 指标开启计数
+}
+
+// Enable metrics, return the function to disable it.
+// Metrics stay enabled until every enabling is disabled, the counters are kept.
+func EnableMetrics() func() {
+	metrics.mu.Lock()
+	if metrics.since.IsZero() {
+		metrics.since = time.Now()
+	}
+	metrics.mu.Unlock()
+	atomic.AddInt32(&metrics.enabled, 1)
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			atomic.AddInt32(&metrics.enabled, -1)
+		})
+	}
+}
+
+// Report whether metrics are enabled
+func IsMetricsEnabled() bool {
+	return atomic.LoadInt32(&metrics.enabled) > 0
+}
+
+// Return a snapshot of the metrics
+func Stats() Metrics {
+	s := Metrics{
+		Created:      atomic.LoadUint64(&metrics.created),
+		Goroutines:   atomic.LoadInt64(&metrics.goroutines),
+		QueueLatency: metrics.queueLatency.snapshot(),
+		RunDuration:  metrics.runDuration.snapshot(),
+	}
+	settled := uint64(0)
+	for i := range metrics.settled {
+		s.Settled[i] = atomic.LoadUint64(&metrics.settled[i])
+		settled += s.Settled[i]
+	}
+	if s.Created > settled {
+		s.Pending = s.Created - settled
+	}
+	metrics.mu.Lock()
+	if !metrics.since.IsZero() {
+		s.Uptime = time.Since(metrics.since)
+	}
+	metrics.mu.Unlock()
+	return s
+}
+
+// Count the new task if metrics are enabled, must be called before the task is published
+func meterCreated(task *TaskImpl) {
+	if IsMetricsEnabled() {
+		task.state |= optMetered
+		atomic.AddUint64(&metrics.created, 1)
+	}
+}
+
+// Count the task being terminated with the state if it is counted on creation,
+// called while the task is locked, before its waiters are released.
+func meterSettled(task *TaskImpl, state uint32) {
+	if stateIs(task, optMetered) {
+		atomic.AddUint64(&metrics.settled[stateOf(state)], 1)
+	}
+}
+
+// Start timing if metrics are enabled, return the zero time otherwise
+func meterStart() time.Time {
+	if IsMetricsEnabled() {
+		return time.Now()
+	}
+	return time.Time{}
+}
+
+// Observe the queue latency of a follower started at the time
+func meterQueued(start time.Time) {
+	if !start.IsZero() {
+		metrics.queueLatency.observe(time.Since(start))
+	}
+}
+
+// Observe the run duration of a body started at the time
+func meterRun(start time.Time) {
+	if !start.IsZero() {
+		metrics.runDuration.observe(time.Since(start))
+	}
+}
+
+// Start a goroutine running fn, count it while it runs if metrics are enabled
+func spawn(fn func()) {
+	if !IsMetricsEnabled() {
+		go fn()
+		return
+	}
+	atomic.AddInt64(&metrics.goroutines, 1)
+	go func() {
+		defer atomic.AddInt64(&metrics.goroutines, -1)
+		fn()
+	}()
+}
@@ -0,0 +1,59 @@
+package task_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pierre-primary/go-task"
+)
+
+func Test_Metrics(t *testing.T) {
+	disable := task.EnableMetrics()
+	defer disable()
+	before := task.Stats()
+
+	release := make(chan struct{})
+	running := make(chan struct{})
+	pending := task.Run(func() (any, error) {
+		close(running)
+		<-release
+		return nil, nil
+	})
+	<-running
+	if s := task.Stats(); s.Pending <= before.Pending || s.Goroutines <= before.Goroutines {
+		t.Error("未统计未结束的任务", s.Pending, s.Goroutines)
+	}
+	close(release)
+	pending.Wait()
+
+	task.Run(func() (any, error) {
+		time.Sleep(time.Millisecond)
+		return nil, errors.New("Reject")
+	}).Catch(func(error) (any, error) {
+		return nil, nil
+	}).Wait()
+
+	after := task.Stats()
+	if after.Created-before.Created < 3 {
+		t.Error("错误的创建数量", after.Created-before.Created)
+	}
+	if after.Settled[task.STATE_COMPLETED]-before.Settled[task.STATE_COMPLETED] < 2 ||
+		after.Settled[task.STATE_FAULTED]-before.Settled[task.STATE_FAULTED] < 1 {
+		t.Error("错误的结束数量", after.Settled, before.Settled)
+	}
+	if after.QueueLatency.Count-before.QueueLatency.Count < 1 {
+		t.Error("未统计排队延迟")
+	}
+	if after.RunDuration.Count-before.RunDuration.Count < 3 || after.RunDuration.Sum-before.RunDuration.Sum < time.Millisecond {
+		t.Error("未统计执行时长", after.RunDuration.Count, after.RunDuration.Sum)
+	}
+
+	// a panicking body is timed as well
+	task.Run(func() (any, error) {
+		panic("boom")
+	}).Wait()
+	if s := task.Stats(); s.RunDuration.Count == after.RunDuration.Count {
+		t.Error("未统计 panic 的执行时长")
+	}
+}
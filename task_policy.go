@@ -0,0 +1,95 @@
+package task
+
+import (
+	"context"
+	"log"
+	"runtime"
+	"runtime/debug"
+	"sync/atomic"
+)
+
+// 策略定义
+//
+// 全局策略通过 SetPolicy 设置；使用 WithPolicy 返回的上下文创建的任务（及其 Follower）使用该上下文的策略。
+type Policy struct {
+	// 失败任务的错误从未通过 Error / Return / Then / Catch 等方式被观察，且任务被垃圾回收时调用
+	OnUnobservedError func(task Task, err error)
+	// 任务调用 PanicForce 时调用，调用后任务以 PanicError 失败；为空时使进程崩溃（默认）
+	OnForcePanic func(task Task, value interface{})
+}
+
+type policyKey struct{}
+
+var globalPolicy atomic.Pointer[Policy]
+
+func init() {
+	globalPolicy.Store(&Policy{})
+}
+
+// Set the global policy
+func SetPolicy(policy Policy) {
+	globalPolicy.Store(&policy)
+}
+
+// Get the global policy
+func GlobalPolicy() Policy {
+	return *globalPolicy.Load()
+}
+
+// Return a context that applies the policy to the tasks created with it
+func WithPolicy(ctx context.Context, policy Policy) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, policyKey{}, &policy)
+}
+
+// Get the policy of the context
+func policyOf(ctx context.Context) *Policy {
+	policy, _ := ctx.Value(policyKey{}).(*Policy)
+	return policy
+}
+
+// Get the policy of the task
+func taskPolicy(task *TaskImpl) *Policy {
+	if task.ext != nil && task.ext.policy != nil {
+		return task.ext.policy
+	}
+	return globalPolicy.Load()
+}
+
+// Log the value of ForcePanic, can be used as Policy.OnForcePanic
+func LogForcePanic(task Task, value interface{}) {
+	log.Printf("Task: force panic: %v\n%s", value, debug.Stack())
+}
+
+// ------------------------------------------------------------------------------------------------------
+/* Policy Apply */
+
+// Watch the faulted task until it is garbage-collected
+func watchUnobserved(task *TaskImpl) {
+	if taskPolicy(task).OnUnobservedError != nil {
+		runtime.SetFinalizer(task, finalizeUnobserved)
+	}
+}
+
+func finalizeUnobserved(task *TaskImpl) {
+	if stateIs(task, optObserved) {
+		return
+	}
+	if handler := taskPolicy(task).OnUnobservedError; handler != nil {
+		err, _ := task.data.(error)
+		handler(task, err)
+	}
+}
+
+// Handle ForcePanic, must be called in the deferred function which recovered.
+func handleForcePanic(task *TaskImpl, v *ForcePanic) {
+	handler := taskPolicy(task).OnForcePanic
+	if handler == nil {
+		panic(v.msg)
+	}
+	handler(task, v.msg)
+	setOption(task, optObserved)
+	terminate(task, flagFailed, &PanicError{Value: v.msg, Stack: debug.Stack()})
+}
@@ -0,0 +1,60 @@
+package task_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/pierre-primary/go-task"
+)
+
+func Test_Policy(t *testing.T) {
+	t.Run("Unobserved", func(t *testing.T) {
+		unobserved := make(chan error, 1)
+		ctx := task.WithPolicy(context.Background(), task.Policy{
+			OnUnobservedError: func(_ task.Task, err error) {
+				unobserved <- err
+			},
+		})
+		rejectErr := errors.New("Reject")
+		func() {
+			task.Run(func() (any, error) {
+				return nil, rejectErr
+			}, ctx).Wait()
+		}()
+		timeout := time.After(time.Second)
+		for {
+			runtime.GC()
+			select {
+			case err := <-unobserved:
+				if err != rejectErr {
+					t.Error("错误的结果", err)
+				}
+				return
+			case <-timeout:
+				t.Fatal("未触发 OnUnobservedError")
+			case <-time.After(time.Millisecond):
+			}
+		}
+	})
+	t.Run("ForcePanic", func(t *testing.T) {
+		var value any
+		ctx := task.WithPolicy(context.Background(), task.Policy{
+			OnForcePanic: func(_ task.Task, v any) {
+				value = v
+			},
+		})
+		err := task.Run(func() (any, error) {
+			task.PanicForce("ForcePanic")
+			return nil, nil
+		}, ctx).Then(func(any) (any, error) {
+			return nil, nil
+		}).Error()
+		var pe *task.PanicError
+		if value != "ForcePanic" || !errors.As(err, &pe) || pe.Value != "ForcePanic" {
+			t.Error("错误的结果", value, err)
+		}
+	})
+}
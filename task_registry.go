@@ -0,0 +1,178 @@
+package task
+
+import (
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 任务登记表
+//
+// 开启跟踪后（EnableTracking），新创建的任务被登记，直到任务结束或跟踪全部关闭；
+// 可通过 LiveTasks 查看未结束的任务及其创建位置，用于排查泄漏。
+
+// 未结束任务的快照
+type LiveTask struct {
+	ID        uint64    // 登记序号，按创建顺序递增
+	Task      Task      // 任务
+	Site      string    // 创建位置（file:line）
+	Created   time.Time // 创建时间
+	State     TaskState // 状态
+	Followers int       // 等待中的 Follower 数量
+}
+
+type taskRecord struct {
+	id      uint64
+	site    string
+	created time.Time
+	sub     Task // 等待的子任务（flagWaitSub）
+}
+
+var registry struct {
+	mu      sync.Mutex
+	tasks   map[*TaskImpl]*taskRecord
+	seq     uint64
+	enabled int32 // 跟踪开启计数
+	tracked int32 // 已登记的任务数量
+}
+
+const packagePrefix = "github.com/pierre-primary/go-task."
+
+// Enable tracking of newly created tasks, return the function to disable it.
+// Tracking stays enabled until every enabling is disabled, then the registered tasks are released.
+func EnableTracking() func() {
+	atomic.AddInt32(&registry.enabled, 1)
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			if atomic.AddInt32(&registry.enabled, -1) == 0 {
+				releaseTracked()
+			}
+		})
+	}
+}
+
+// Release the registered tasks once tracking is disabled, so that the pending tasks can be collected
+func releaseTracked() {
+	registry.mu.Lock()
+	if atomic.LoadInt32(&registry.enabled) == 0 {
+		registry.tasks = nil
+		atomic.StoreInt32(&registry.tracked, 0)
+	}
+	registry.mu.Unlock()
+}
+
+// Report whether tracking is enabled
+func IsTracking() bool {
+	return atomic.LoadInt32(&registry.enabled) > 0
+}
+
+// Return the last registered ID, tasks created afterwards have greater IDs
+func TrackingSeq() uint64 {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	return registry.seq
+}
+
+// Find the caller outside this package
+func callerSite(skip int) string {
+	var pcs [16]uintptr
+	n := runtime.Callers(skip+1, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, packagePrefix) {
+			return frame.File + ":" + strconv.Itoa(frame.Line)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
+
+// Register the new task if tracking is enabled
+func track(task *TaskImpl) {
+	if !IsTracking() {
+		return
+	}
+	record := &taskRecord{site: callerSite(3), created: time.Now()}
+	registry.mu.Lock()
+	// tracking is disabled meanwhile
+	if atomic.LoadInt32(&registry.enabled) == 0 {
+		registry.mu.Unlock()
+		return
+	}
+	if registry.tasks == nil {
+		registry.tasks = make(map[*TaskImpl]*taskRecord)
+	}
+	registry.seq++
+	record.id = registry.seq
+	registry.tasks[task] = record
+	atomic.AddInt32(&registry.tracked, 1)
+	registry.mu.Unlock()
+}
+
+// Unregister the done task
+func untrack(task *TaskImpl) {
+	if atomic.LoadInt32(&registry.tracked) == 0 {
+		return
+	}
+	registry.mu.Lock()
+	if _, ok := registry.tasks[task]; ok {
+		delete(registry.tasks, task)
+		atomic.AddInt32(&registry.tracked, -1)
+	}
+	registry.mu.Unlock()
+}
+
+// Record the sub task the registered task waits for
+func trackSub(task *TaskImpl, sub Task) {
+	if atomic.LoadInt32(&registry.tracked) == 0 {
+		return
+	}
+	registry.mu.Lock()
+	if record, ok := registry.tasks[task]; ok {
+		record.sub = sub
+	}
+	registry.mu.Unlock()
+}
+
+// Return the follower tasks waiting for the task
+func followersOf(task *TaskImpl) []*TaskImpl {
+	var flwTasks []*TaskImpl
+	if lockStateIfNot(task, lockFlws, checkDone) {
+		for flw := task.flws; flw != nil; flw = flw.next {
+			flwTasks = append(flwTasks, flw.task)
+		}
+		unlockStateAndSet(task, lockFlws, 0)
+	}
+	return flwTasks
+}
+
+// Return the registered tasks that are not done, in the order of creation
+func LiveTasks() []LiveTask {
+	registry.mu.Lock()
+	tasks := make([]LiveTask, 0, len(registry.tasks))
+	for task, record := range registry.tasks {
+		tasks = append(tasks, LiveTask{
+			ID:      record.id,
+			Task:    task,
+			Site:    record.site,
+			Created: record.created,
+		})
+	}
+	registry.mu.Unlock()
+	for i := range tasks {
+		task := tasks[i].Task.(*TaskImpl)
+		tasks[i].State = task.State()
+		tasks[i].Followers = len(followersOf(task))
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].ID < tasks[j].ID
+	})
+	return tasks
+}
@@ -0,0 +1,68 @@
+package task_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/pierre-primary/go-task"
+)
+
+func Test_Registry(t *testing.T) {
+	disable := task.EnableTracking()
+	pending, resolve, _ := task.New()
+	defer resolve(nil)
+	found := func() bool {
+		for _, live := range task.LiveTasks() {
+			if live.Task == pending {
+				return true
+			}
+		}
+		return false
+	}
+	if !found() {
+		t.Fatal("未登记任务")
+	}
+	// disabling tracking releases the tasks that never settle
+	disable()
+	defer task.EnableTracking()()
+	if found() {
+		t.Error("关闭跟踪后仍登记任务")
+	}
+}
+
+func Test_RegistryConcurrent(t *testing.T) {
+	defer task.EnableTracking()()
+	ctx := task.WithLinkedCancel(task.WithName(context.Background(), "step"))
+	started := make(chan struct{})
+	stop := make(chan struct{})
+	dumped := make(chan struct{})
+	go func() {
+		defer close(dumped)
+		for i := 0; ; i++ {
+			task.LiveTasks()
+			task.DumpGraph(io.Discard, task.GRAPH_TEXT)
+			if i == 0 {
+				close(started)
+			}
+			select {
+			case <-stop:
+				return
+			default:
+			}
+		}
+	}()
+	<-started
+	// create followers while the registry is read
+	src, resolve, _ := task.New()
+	flws := make([]task.Task, 0, 1000)
+	for i := 0; i < cap(flws); i++ {
+		flws = append(flws, src.Then(func(any) (any, error) {
+			return nil, nil
+		}, ctx))
+	}
+	close(stop)
+	<-dumped
+	resolve(nil)
+	task.WhenAll(flws...).Wait()
+}
@@ -0,0 +1,164 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// 退避策略接口定义
+type Backoff interface {
+	// Return the delay before the given retry, attempt starts at 1, prev is the previous delay.
+	Next(attempt int, prev time.Duration) time.Duration
+}
+
+// 退避策略适配函数
+type BackoffFunc func(attempt int, prev time.Duration) time.Duration
+
+func (fn BackoffFunc) Next(attempt int, prev time.Duration) time.Duration {
+	return fn(attempt, prev)
+}
+
+// Wait the same delay before each retry
+func ConstantBackoff(d time.Duration) Backoff {
+	return BackoffFunc(func(int, time.Duration) time.Duration {
+		return d
+	})
+}
+
+// Wait initial * factor^(attempt-1) before each retry, capped at max (0 means no cap)
+func ExponentialBackoff(initial, max time.Duration, factor float64) Backoff {
+	if factor < 1 {
+		factor = 2
+	}
+	return BackoffFunc(func(attempt int, _ time.Duration) time.Duration {
+		d := float64(initial) * math.Pow(factor, float64(attempt-1))
+		if max > 0 && d > float64(max) {
+			return max
+		}
+		if d > math.MaxInt64 {
+			return time.Duration(math.MaxInt64)
+		}
+		return time.Duration(d)
+	})
+}
+
+// Wait a random delay between base and 3 * prev before each retry, capped at max (0 means no cap)
+func DecorrelatedJitterBackoff(base, max time.Duration) Backoff {
+	return BackoffFunc(func(_ int, prev time.Duration) time.Duration {
+		if prev < base {
+			prev = base
+		}
+		d := base
+		if upper := prev * 3; upper > base {
+			d += time.Duration(rand.Int63n(int64(upper - base)))
+		}
+		if max > 0 && d > max {
+			return max
+		}
+		return d
+	})
+}
+
+var unboundedRetryError = errors.New("Task: retry policy without MaxAttempts, MaxElapsed or Backoff")
+
+// 重试策略
+//
+// MaxAttempts, MaxElapsed, Backoff 至少设置一项，否则 Retry 会 panic；
+// 只设置 Backoff 时不限制重试次数，需要通过上下文取消。
+type RetryPolicy struct {
+	MaxAttempts int                                               // 最大尝试次数（包括首次），0 表示不限制
+	MaxElapsed  time.Duration                                     // 最长总耗时（包括等待），0 表示不限制
+	Backoff     Backoff                                           // 退避策略，为空时立即重试
+	Retryable   func(err error) bool                              // 判断错误是否可重试，为空时所有错误均可重试
+	OnRetry     func(attempt int, err error, delay time.Duration) // 每次重试等待前调用
+}
+
+// Check whether the failed attempt can be retried, and return the delay
+func (policy *RetryPolicy) next(attempt int, err error, prev time.Duration, elapsed time.Duration) (time.Duration, bool) {
+	if policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts {
+		return 0, false
+	}
+	if policy.Retryable != nil && !policy.Retryable(err) {
+		return 0, false
+	}
+	var delay time.Duration
+	if policy.Backoff != nil {
+		delay = policy.Backoff.Next(attempt, prev)
+	}
+	if policy.MaxElapsed > 0 && elapsed+delay >= policy.MaxElapsed {
+		return 0, false
+	}
+	return delay, true
+}
+
+/* Retry */
+type retrier struct {
+	fn      RunFunc
+	policy  RetryPolicy
+	ctx     context.Context
+	clock   Clock
+	start   time.Time
+	attempt int
+	delay   time.Duration
+	mu      sync.Mutex
+	timer   Timer // 等待下次尝试的计时器
+	stopped bool  // 任务已结束，不再调度
+}
+
+// Make an attempt, and schedule the next attempt on failure
+func (r *retrier) Call(task *TaskImpl) {
+	r.attempt++
+	rs, err := r.fn()
+	if err == nil {
+		resolve(task, rs)
+		return
+	}
+	var ok bool
+	if r.delay, ok = r.policy.next(r.attempt, err, r.delay, r.clock.Now().Sub(r.start)); !ok {
+		reject(task, err)
+		return
+	}
+	if r.policy.OnRetry != nil {
+		r.policy.OnRetry(r.attempt, err, r.delay)
+	}
+	r.mu.Lock()
+	if !r.stopped {
+		r.timer = r.clock.AfterFunc(r.delay, func() {
+			startTask(task, r, r.ctx)
+		})
+	}
+	r.mu.Unlock()
+}
+
+// Stop the pending attempt, no more attempts are scheduled
+func (r *retrier) stop() {
+	r.mu.Lock()
+	r.stopped = true
+	if r.timer != nil {
+		r.timer.Stop()
+	}
+	r.mu.Unlock()
+}
+
+func Retry(fn RunFunc, policy RetryPolicy, ctxs ...context.Context) Task {
+	if policy.MaxAttempts <= 0 && policy.MaxElapsed <= 0 && policy.Backoff == nil {
+		panic(unboundedRetryError)
+	}
+	ctx := firstContext(ctxs, nil)
+	if ctx != nil && isCanceledContext(ctx) {
+		return Cancel(ctx.Err())
+	}
+	task, taskCtx := newCancelableTask(ctx)
+	clock := clockOf(ctx)
+	r := &retrier{fn: fn, policy: policy, ctx: ctx, clock: clock, start: clock.Now()}
+	// settle immediately when canceled during the delay, and stop the timer
+	context.AfterFunc(taskCtx, func() {
+		cancel(task, taskCtx.Err())
+		r.stop()
+	})
+	return startTask(task, r, ctx)
+}
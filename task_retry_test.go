@@ -0,0 +1,96 @@
+package task_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pierre-primary/go-task"
+	"github.com/pierre-primary/go-task/tasktest"
+)
+
+func Test_Retry(t *testing.T) {
+	t.Run("Resolve", func(t *testing.T) {
+		n := 0
+		retries := 0
+		rs, err := task.Retry(func() (any, error) {
+			if n++; n < 3 {
+				return nil, errors.New("Retry")
+			}
+			return n, nil
+		}, task.RetryPolicy{
+			MaxAttempts: 5,
+			Backoff:     task.ExponentialBackoff(time.Millisecond, 4*time.Millisecond, 2),
+			OnRetry: func(attempt int, err error, delay time.Duration) {
+				retries++
+			},
+		}).Return()
+		if err != nil || rs != 3 || retries != 2 {
+			t.Error("错误的结果", rs, err, retries)
+		}
+	})
+	t.Run("MaxAttempts", func(t *testing.T) {
+		n := 0
+		err := task.Retry(func() (any, error) {
+			n++
+			return nil, errors.New("Retry")
+		}, task.RetryPolicy{MaxAttempts: 3}).Error()
+		if err == nil || n != 3 {
+			t.Error("错误的结果", err, n)
+		}
+	})
+	t.Run("Retryable", func(t *testing.T) {
+		fatal := errors.New("Fatal")
+		n := 0
+		err := task.Retry(func() (any, error) {
+			n++
+			return nil, fatal
+		}, task.RetryPolicy{MaxAttempts: 3, Retryable: func(err error) bool {
+			return err != fatal
+		}}).Error()
+		if err != fatal || n != 1 {
+			t.Error("错误的结果", err, n)
+		}
+	})
+	t.Run("Cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		tk := task.Retry(func() (any, error) {
+			return nil, errors.New("Retry")
+		}, task.RetryPolicy{Backoff: task.DecorrelatedJitterBackoff(time.Millisecond, 10*time.Millisecond)}, ctx)
+		time.AfterFunc(5*time.Millisecond, cancel)
+		if tk.Wait(); !tk.IsCanceled() {
+			t.Error("错误的状态", tk.State())
+		}
+	})
+	t.Run("StopTimer", func(t *testing.T) {
+		clock := tasktest.NewClock(time.Unix(0, 0))
+		ctx, cancel := context.WithCancel(task.WithClock(context.Background(), clock))
+		tk := task.Retry(func() (any, error) {
+			return nil, errors.New("Retry")
+		}, task.RetryPolicy{Backoff: task.ConstantBackoff(time.Minute)}, ctx)
+		for clock.Pending() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		cancel()
+		if tk.Wait(); !tk.IsCanceled() {
+			t.Error("错误的状态", tk.State())
+		}
+		for i := 0; clock.Pending() != 0; i++ {
+			if i > 1000 {
+				t.Fatal("未停止计时器")
+			}
+			time.Sleep(time.Millisecond)
+		}
+	})
+	t.Run("Unbounded", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("未 panic")
+			}
+		}()
+		task.Retry(func() (any, error) {
+			return nil, errors.New("Retry")
+		}, task.RetryPolicy{})
+	})
+}
@@ -0,0 +1,174 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var scopeClosedError = errors.New("Task: scope closed")
+
+func ScopeClosed() error {
+	return scopeClosedError
+}
+
+// 结构化并发作用域
+//
+// Scope 持有并汇合（join）通过它启动的子任务：Close 之后，所有子任务结束时 Scope 才结束，
+// 并解析为按启动顺序排列的子任务结果（[]interface{}），或以第一个子任务错误失败；
+// 没有子任务失败而有子任务取消时，以第一个取消的原因取消。
+// 默认第一个子任务失败时取消子任务的上下文和其余子任务（SetFailFast 可关闭）。
+// Scope 本身也是一个 Task，可以继续 Then / Catch。
+type Scope struct {
+	*TaskImpl
+	ctx      context.Context
+	stop     context.CancelFunc
+	mu       sync.Mutex
+	children []Task
+	remain   int
+	closed   bool
+	failFast bool
+	err      error // 第一个失败的子任务的错误
+	canceled error // 第一个取消的子任务的原因
+}
+
+// Create a scope, the children run with a context derived from the given context
+func NewScope(ctxs ...context.Context) *Scope {
+	scope := &Scope{failFast: true}
+	task, ctx := newCancelableTask(firstContext(ctxs, nil))
+	ctx, scope.stop = context.WithCancel(ctx)
+	stop := task.ext.stop
+	task.ext.stop = func(task *TaskImpl) {
+		stop(task)
+		// cancel the remaining children when the scope is canceled
+		if stateIs(task, flagCanceled) {
+			err, _ := task.data.(error)
+			scope.cancelChildren(err)
+		}
+	}
+	scope.TaskImpl = task
+	scope.ctx = ctx
+	return scope
+}
+
+// Set whether the first failure cancels the other children, default is true
+func (scope *Scope) SetFailFast(failFast bool) {
+	scope.mu.Lock()
+	scope.failFast = failFast
+	scope.mu.Unlock()
+}
+
+// Return the context of the children, it is canceled when the scope is done or fails fast
+func (scope *Scope) Context() context.Context {
+	return scope.ctx
+}
+
+// Run a child task in the scope
+func (scope *Scope) Run(fn RunFunc) Task {
+	return scope.add(func() Task {
+		return Run(fn, scope.ctx)
+	})
+}
+
+// Run a child task in the scope, fn receives the context of the children
+func (scope *Scope) RunContext(fn RunContextFunc) Task {
+	return scope.add(func() Task {
+		return RunContext(fn, scope.ctx)
+	})
+}
+
+// Add a task started outside the scope as a child
+func (scope *Scope) Join(t Task) Task {
+	return scope.add(func() Task {
+		return t
+	})
+}
+
+func (scope *Scope) add(start func() Task) Task {
+	scope.mu.Lock()
+	if scope.closed || scope.IsDone() {
+		scope.mu.Unlock()
+		return Reject(scopeClosedError)
+	}
+	child := start()
+	if child == nil {
+		child = Resolve()
+	}
+	scope.children = append(scope.children, child)
+	scope.remain++
+	scope.mu.Unlock()
+	observe(child, scope.settle)
+	return child
+}
+
+func (scope *Scope) settle(child Task) {
+	var cancelErr error
+	scope.mu.Lock()
+	switch {
+	case child.IsFaulted() && scope.err == nil:
+		scope.err = child.Error()
+		if scope.failFast {
+			cancelErr = scope.err
+		}
+	case child.IsCanceled() && scope.canceled == nil:
+		scope.canceled = child.Error()
+	}
+	scope.remain--
+	scope.mu.Unlock()
+	if cancelErr != nil {
+		scope.stop()
+		scope.cancelChildren(cancelErr)
+	}
+	scope.tryFinish()
+}
+
+// Cancel all children that are not done
+func (scope *Scope) cancelChildren(err error) {
+	scope.mu.Lock()
+	children := append([]Task(nil), scope.children...)
+	scope.mu.Unlock()
+	for _, child := range children {
+		cancelTask(child, err)
+	}
+}
+
+// Settle the scope if it is closed and all children are done
+func (scope *Scope) tryFinish() {
+	scope.mu.Lock()
+	if !scope.closed || scope.remain > 0 {
+		scope.mu.Unlock()
+		return
+	}
+	err, canceled := scope.err, scope.canceled
+	var results []interface{}
+	if err == nil && canceled == nil {
+		results = make([]interface{}, len(scope.children))
+		for i, child := range scope.children {
+			results[i] = child.Result()
+		}
+	}
+	scope.mu.Unlock()
+	switch {
+	case err != nil:
+		reject(scope.TaskImpl, err)
+	case canceled != nil:
+		cancel(scope.TaskImpl, canceled)
+	default:
+		resolve(scope.TaskImpl, results)
+	}
+}
+
+// Stop accepting children, the scope is done once all children are done
+func (scope *Scope) Close() *Scope {
+	scope.mu.Lock()
+	scope.closed = true
+	scope.mu.Unlock()
+	scope.tryFinish()
+	return scope
+}
+
+// Close the scope and wait for all children to be done
+func (scope *Scope) Wait(ctxs ...context.Context) Task {
+	scope.Close()
+	return scope.TaskImpl.Wait(ctxs...)
+}
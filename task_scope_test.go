@@ -0,0 +1,78 @@
+package task_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pierre-primary/go-task"
+)
+
+func Test_Scope(t *testing.T) {
+	t.Run("Resolve", func(t *testing.T) {
+		scope := task.NewScope()
+		scope.Run(func() (any, error) {
+			return 1, nil
+		})
+		scope.Run(func() (any, error) {
+			time.Sleep(time.Millisecond)
+			return 2, nil
+		})
+		rs, err := scope.Wait().Return()
+		if err != nil || !reflect.DeepEqual(rs, []interface{}{1, 2}) {
+			t.Error("错误的结果", rs, err)
+		}
+		if err := scope.Run(func() (any, error) { return nil, nil }).Error(); err != task.ScopeClosed() {
+			t.Error("错误的结果", err)
+		}
+	})
+	t.Run("FailFast", func(t *testing.T) {
+		scope := task.NewScope()
+		failErr := errors.New("Fail")
+		sibling := scope.RunContext(func(ctx context.Context) (any, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+		scope.Run(func() (any, error) {
+			return nil, failErr
+		})
+		// the context of the children is canceled before the scope is closed
+		select {
+		case <-scope.Context().Done():
+		case <-time.After(time.Second):
+			t.Error("上下文未取消")
+		}
+		if err := scope.Wait().Error(); err != failErr {
+			t.Error("错误的结果", err)
+		}
+		if !sibling.IsCanceled() {
+			t.Error("错误的状态", sibling.State())
+		}
+	})
+	t.Run("Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		scope := task.NewScope(ctx)
+		scope.RunContext(func(ctx context.Context) (any, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+		cancel()
+		if scope.Wait(); !scope.IsCanceled() || scope.Error() != context.Canceled {
+			t.Error("错误的状态", scope.State(), scope.Error())
+		}
+	})
+	t.Run("Chain", func(t *testing.T) {
+		scope := task.NewScope()
+		scope.Run(func() (any, error) {
+			return 1, nil
+		})
+		rs := scope.Close().Then(func(rs any) (any, error) {
+			return len(rs.([]interface{})), nil
+		}).Result()
+		if rs != 1 {
+			t.Error("错误的结果", rs)
+		}
+	})
+}
@@ -27,6 +27,7 @@ var starterPool = sync.Pool{
 func (starter *Starter) release() {
 	starter.task = nil
 	starter.caller = nil
+	starter.ctx = nil
 	starterPool.Put(starter)
 }
 
@@ -41,7 +42,7 @@ func assignStarter(task *TaskImpl, caller StartCaller, ctx context.Context) *Sta
 
 // Asynchronous execute starter task
 func asyncExecStarter(starter *Starter) {
-	go func() {
+	err := executorOf(starter.ctx).Execute(func() {
 		// small closure way
 		// donot modify closure variable (starter),
 		// avoid additional memory allocation.
@@ -56,25 +57,55 @@ func asyncExecStarter(starter *Starter) {
 			return
 		}
 
-		syncExecStarter(task, caller)
-	}()
+		if hasLabels(task) {
+			doLabeled(task, func(context.Context) {
+				syncExecStarter(task, caller, ctx)
+			})
+			return
+		}
+		syncExecStarter(task, caller, ctx)
+	})
+	// schedule failed
+	if err != nil {
+		task := starter.task
+		starter.release()
+		reject(task, err)
+	}
 }
 
 // Synchronous execute starter task
-func syncExecStarter(task *TaskImpl, caller StartCaller) {
+func syncExecStarter(task *TaskImpl, caller StartCaller, ctx context.Context) {
+	// canceled before started
+	if stateIs(task, checkDone) {
+		return
+	}
+	// start timing
+	stopTimeout := armTimeout(task, ctx)
 	// safe exit
 	defer func() {
 		if r := recover(); r != nil {
-			reject(task, r)
+			reject(task, recoveredIn(task, r))
+		}
+		// the task may be done later (Start), keep timing in that case
+		if stopTimeout != nil && stateIs(task, checkDone) {
+			stopTimeout()
 		}
 	}()
 	// call
+	if span := spanOf(task); span != nil {
+		span.Start()
+	}
+	defer meterRun(meterStart())
 	caller.Call(task)
 }
 
 // Create a starter task
 func newStarter(caller StartCaller, ctx context.Context) *TaskImpl {
-	task := newTask()
+	return startTask(newContextTask(ctx), caller, ctx)
+}
+
+// Start an initial task
+func startTask(task *TaskImpl, caller StartCaller, ctx context.Context) *TaskImpl {
 	starter := assignStarter(task, caller, ctx)
 	// async exec
 	asyncExecStarter(starter)
@@ -0,0 +1,318 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var noValueError = errors.New("Task: no value")
+
+func NoValue() error {
+	return noValueError
+}
+
+// 流回调函数定义
+type (
+	// 发送一个值，阻塞直到值被消费，流结束（取消）时返回 false
+	EmitFunc = func(interface{}) bool
+	// 生产者函数，返回后流结束；返回 nil 时流完成，返回错误时流失败，上下文取消时流取消
+	StreamFunc = func(ctx context.Context, emit EmitFunc) error
+)
+
+// 异步流
+//
+// Stream 异步地产生多个值，并以 TaskState 描述结束状态（完成，失败，取消）。
+// 值只会被交付一次：多个消费者同时调用 Next 时，各自得到不同的值。
+type Stream struct {
+	task *TaskImpl
+	ch   chan interface{}
+}
+
+// Error of canceled upstream, the stream returning it is canceled with the cause
+type streamCanceled struct {
+	cause error
+}
+
+func (e *streamCanceled) Error() string {
+	return toString(e.cause)
+}
+
+type streamCaller struct {
+	stream *Stream
+	fn     StreamFunc
+	ctx    context.Context
+}
+
+func (body *streamCaller) Call(task *TaskImpl) {
+	ctx := body.ctx
+	ch := body.stream.ch
+	err := body.fn(ctx, func(v interface{}) bool {
+		select {
+		case ch <- v:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	})
+	var sc *streamCanceled
+	switch {
+	case errors.As(err, &sc):
+		cancel(task, sc.cause)
+	case ctx.Err() != nil:
+		cancel(task, ctx.Err())
+	case err != nil:
+		reject(task, err)
+	default:
+		resolve(task, nil)
+	}
+}
+
+// Create a stream, fn runs asynchronously and its context is canceled when the stream is done.
+func NewStream(fn StreamFunc, ctxs ...context.Context) *Stream {
+	ctx := firstContext(ctxs, nil)
+	stream := &Stream{ch: make(chan interface{})}
+	if ctx != nil && isCanceledContext(ctx) {
+		stream.task = Cancel(ctx.Err()).(*TaskImpl)
+		return stream
+	}
+	task, taskCtx := newCancelableTask(ctx)
+	stream.task = task
+	startTask(task, &streamCaller{stream: stream, fn: fn, ctx: taskCtx}, ctx)
+	return stream
+}
+
+// Create a stream that emits the values
+func StreamOf(values ...interface{}) *Stream {
+	return NewStream(func(ctx context.Context, emit EmitFunc) error {
+		for _, v := range values {
+			if !emit(v) {
+				return ctx.Err()
+			}
+		}
+		return nil
+	})
+}
+
+// ------------------------------------------------------------------------------------------------------
+// 流状态
+
+// Receive the next value, return false when the stream is done or the context is canceled
+func (stream *Stream) Next(ctxs ...context.Context) (interface{}, bool) {
+	ctx := firstContext(ctxs, context.TODO())
+	select {
+	case v := <-stream.ch:
+		return v, true
+	case <-done(stream.task):
+		return nil, false
+	case <-ctx.Done():
+		return nil, false
+	}
+}
+
+// Return the task that settles with the final state of the stream
+func (stream *Stream) Task() Task {
+	return stream.task
+}
+
+func (stream *Stream) State() TaskState {
+	return stream.task.State()
+}
+
+func (stream *Stream) Done() chan struct{} {
+	return stream.task.Done()
+}
+
+// Wait for the stream to be done, and return its error
+func (stream *Stream) Err() error {
+	return stream.task.Error()
+}
+
+// Cancel the stream, the context of the producer is canceled as well
+func (stream *Stream) Cancel(cause error) bool {
+	return stream.task.Cancel(cause)
+}
+
+// Wait for the stream to be done, and return the error that passes its final state
+func (stream *Stream) end() error {
+	switch stream.task.Wait().State() {
+	case STATE_COMPLETED:
+		return nil
+	case STATE_CANCELED:
+		return &streamCanceled{cause: stream.task.Error()}
+	default:
+		return stream.task.Error()
+	}
+}
+
+// ------------------------------------------------------------------------------------------------------
+// 流操作
+
+// Create a stream consuming this stream, this stream is canceled when the new stream is done
+func (stream *Stream) derive(fn StreamFunc) *Stream {
+	derived := NewStream(func(ctx context.Context, emit EmitFunc) error {
+		defer stream.Cancel(nil)
+		return fn(ctx, emit)
+	})
+	// fn never runs if the new stream is canceled before it starts
+	observe(derived.task, func(Task) {
+		stream.Cancel(nil)
+	})
+	return derived
+}
+
+// Map each value, the stream fails if fn returns an error
+func (stream *Stream) Map(fn func(interface{}) (interface{}, error)) *Stream {
+	return stream.derive(func(ctx context.Context, emit EmitFunc) error {
+		for v, ok := stream.Next(ctx); ok; v, ok = stream.Next(ctx) {
+			rs, err := fn(v)
+			if err != nil {
+				return err
+			}
+			if !emit(rs) {
+				return ctx.Err()
+			}
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return stream.end()
+	})
+}
+
+// Emit only the values for which fn returns true
+func (stream *Stream) Filter(fn func(interface{}) bool) *Stream {
+	return stream.derive(func(ctx context.Context, emit EmitFunc) error {
+		for v, ok := stream.Next(ctx); ok; v, ok = stream.Next(ctx) {
+			if fn(v) && !emit(v) {
+				return ctx.Err()
+			}
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return stream.end()
+	})
+}
+
+// Emit the first n values, then complete and cancel this stream
+func (stream *Stream) Take(n int) *Stream {
+	return stream.derive(func(ctx context.Context, emit EmitFunc) error {
+		for i := 0; i < n; i++ {
+			v, ok := stream.Next(ctx)
+			if !ok {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				return stream.end()
+			}
+			if !emit(v) {
+				return ctx.Err()
+			}
+		}
+		return nil
+	})
+}
+
+// Emit the values in batches ([]interface{}) of n values, the last batch may be smaller
+func (stream *Stream) Buffer(n int) *Stream {
+	if n <= 0 {
+		n = 1
+	}
+	return stream.derive(func(ctx context.Context, emit EmitFunc) error {
+		batch := make([]interface{}, 0, n)
+		for v, ok := stream.Next(ctx); ok; v, ok = stream.Next(ctx) {
+			if batch = append(batch, v); len(batch) == n {
+				if !emit(batch) {
+					return ctx.Err()
+				}
+				batch = make([]interface{}, 0, n)
+			}
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := stream.end(); err != nil {
+			return err
+		}
+		if len(batch) > 0 && !emit(batch) {
+			return ctx.Err()
+		}
+		return nil
+	})
+}
+
+// Emit the values of all streams as they arrive,
+// complete when all streams complete, or end with the first stream that does not complete.
+func (stream *Stream) Merge(others ...*Stream) *Stream {
+	streams := append([]*Stream{stream}, others...)
+	return NewStream(func(ctx context.Context, emit EmitFunc) error {
+		ctx, stop := context.WithCancel(ctx)
+		defer stop()
+		var (
+			wg    sync.WaitGroup
+			once  sync.Once
+			first error
+		)
+		wg.Add(len(streams))
+		for _, s := range streams {
+			s := s
+			spawn(func() {
+				defer wg.Done()
+				defer s.Cancel(nil)
+				for v, ok := s.Next(ctx); ok; v, ok = s.Next(ctx) {
+					if !emit(v) {
+						return
+					}
+				}
+				if ctx.Err() != nil {
+					return
+				}
+				if err := s.end(); err != nil {
+					once.Do(func() {
+						first = err
+						stop()
+					})
+				}
+			})
+		}
+		wg.Wait()
+		return first
+	})
+}
+
+// ------------------------------------------------------------------------------------------------------
+// 流转任务
+
+// Return a task that resolves to all values ([]interface{}) once the stream completes,
+// or adopts the final state of the stream.
+func (stream *Stream) Collect() Task {
+	var body taskCaller = func(task *TaskImpl) {
+		values := []interface{}{}
+		for v, ok := stream.Next(); ok; v, ok = stream.Next() {
+			values = append(values, v)
+		}
+		if stream.task.Wait().IsCompleted() {
+			resolve(task, values)
+		} else {
+			adopt(task, stream.task)
+		}
+	}
+	return newStarter(body, nil)
+}
+
+// Return a task that resolves to the first value and cancels the stream,
+// or rejects with NoValue if the stream completes without any value.
+func (stream *Stream) First() Task {
+	var body taskCaller = func(task *TaskImpl) {
+		if v, ok := stream.Next(); ok {
+			resolve(task, v)
+			stream.Cancel(nil)
+		} else if stream.task.Wait().IsCompleted() {
+			reject(task, noValueError)
+		} else {
+			adopt(task, stream.task)
+		}
+	}
+	return newStarter(body, nil)
+}
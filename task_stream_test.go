@@ -0,0 +1,106 @@
+package task_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/pierre-primary/go-task"
+)
+
+func Test_Stream(t *testing.T) {
+	t.Run("Operators", func(t *testing.T) {
+		rs, err := task.StreamOf(1, 2, 3, 4, 5, 6, 7).Filter(func(v any) bool {
+			return v.(int)%2 == 1
+		}).Map(func(v any) (any, error) {
+			return v.(int) * 10, nil
+		}).Take(3).Buffer(2).Collect().Return()
+		want := []interface{}{[]interface{}{10, 30}, []interface{}{50}}
+		if err != nil || !reflect.DeepEqual(rs, want) {
+			t.Error("错误的结果", rs, err)
+		}
+	})
+	t.Run("Infinite", func(t *testing.T) {
+		stopped := make(chan struct{})
+		src := task.NewStream(func(ctx context.Context, emit task.EmitFunc) error {
+			defer close(stopped)
+			for i := 0; emit(i); i++ {
+			}
+			return ctx.Err()
+		})
+		rs := src.Take(2).Collect().Result()
+		<-stopped
+		if !reflect.DeepEqual(rs, []interface{}{0, 1}) || !src.Task().Wait().IsCanceled() {
+			t.Error("错误的结果", rs, src.State())
+		}
+	})
+	t.Run("Error", func(t *testing.T) {
+		failErr := errors.New("Fail")
+		s := task.NewStream(func(ctx context.Context, emit task.EmitFunc) error {
+			emit(1)
+			return failErr
+		}).Map(func(v any) (any, error) {
+			return v, nil
+		})
+		if err := s.Collect().Error(); err != failErr || s.State() != task.STATE_FAULTED {
+			t.Error("错误的结果", err, s.State())
+		}
+	})
+	t.Run("Merge", func(t *testing.T) {
+		rs := task.StreamOf(1, 2).Merge(task.StreamOf(3), task.StreamOf(4, 5)).Collect().Result().([]interface{})
+		ints := make([]int, len(rs))
+		for i, v := range rs {
+			ints[i] = v.(int)
+		}
+		sort.Ints(ints)
+		if !reflect.DeepEqual(ints, []int{1, 2, 3, 4, 5}) {
+			t.Error("错误的结果", ints)
+		}
+	})
+	t.Run("First", func(t *testing.T) {
+		if rs := task.StreamOf(1, 2).First().Result(); rs != 1 {
+			t.Error("错误的结果", rs)
+		}
+		if err := task.StreamOf().First().Error(); err != task.NoValue() {
+			t.Error("错误的结果", err)
+		}
+		s := task.StreamOf(1)
+		s.Cancel(nil)
+		if tk := s.First().Wait(); !tk.IsCanceled() {
+			t.Error("错误的状态", tk.State())
+		}
+	})
+	t.Run("CancelIdle", func(t *testing.T) {
+		ops := map[string]func(*task.Stream) *task.Stream{
+			"Map": func(s *task.Stream) *task.Stream {
+				return s.Map(func(v any) (any, error) { return v, nil })
+			},
+			"Filter": func(s *task.Stream) *task.Stream {
+				return s.Filter(func(any) bool { return true })
+			},
+			"Take":   func(s *task.Stream) *task.Stream { return s.Take(2) },
+			"Buffer": func(s *task.Stream) *task.Stream { return s.Buffer(1) },
+		}
+		for name, op := range ops {
+			src := task.NewStream(func(ctx context.Context, emit task.EmitFunc) error {
+				emit(1)
+				<-ctx.Done()
+				return ctx.Err()
+			})
+			s := op(src)
+			// the operator is waiting for the idle producer
+			if _, ok := s.Next(); !ok {
+				t.Fatal("错误的结果", name)
+			}
+			s.Cancel(nil)
+			if s.Task().Wait(); !s.Task().IsCanceled() {
+				t.Error("错误的状态", name, s.State())
+			}
+			if src.Task().Wait(); !src.Task().IsCanceled() {
+				t.Error("错误的状态", name, src.State())
+			}
+		}
+	})
+}
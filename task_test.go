@@ -2,6 +2,7 @@ package task_test
 
 import (
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/pierre-primary/go-task"
@@ -89,3 +90,58 @@ func Test_Error(t *testing.T) {
 		panic("xxx")
 	}).Wait()
 }
+
+func Test_PanicError(t *testing.T) {
+	panicErr := errors.New("Panic")
+	err := task.Resolve().Then(func(any) (any, error) {
+		panic(panicErr)
+	}).Error()
+	var pe *task.PanicError
+	if !errors.As(err, &pe) || pe.Value != panicErr || !errors.Is(err, panicErr) {
+		t.Fatal("错误的结果", err)
+	}
+	if !strings.Contains(string(pe.Stack), "Test_PanicError") {
+		t.Error("错误的调用栈", string(pe.Stack))
+	}
+}
+
+func Test_Finally(t *testing.T) {
+	t.Run("Resolve", func(t *testing.T) {
+		called := false
+		rs := task.Resolve(1).Finally(func() {
+			called = true
+		}).Result()
+		if !called || rs != 1 {
+			t.Error("错误的结果", called, rs)
+		}
+	})
+	t.Run("Cancel", func(t *testing.T) {
+		called := false
+		tk := task.Cancel().FinallyAwait(func() {
+			called = true
+		})
+		if !called || !tk.IsCanceled() {
+			t.Error("错误的结果", called, tk.State())
+		}
+	})
+	t.Run("Panic", func(t *testing.T) {
+		tk := task.Resolve(1).Finally(func() {
+			panic("Finally")
+		}).Wait()
+		if !tk.IsFaulted() {
+			t.Error("错误的状态", tk.State())
+		}
+	})
+}
+
+func Test_OnCancel(t *testing.T) {
+	rs := task.Cancel().Catch(func(err error) (any, error) {
+		t.Error("错误的路径")
+		return nil, nil
+	}).OnCancel(func(err error) (any, error) {
+		return "OnCancel", nil
+	}).Result()
+	if rs != "OnCancel" {
+		t.Error("错误的结果", rs)
+	}
+}
@@ -0,0 +1,100 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// 超时错误
+type TimeoutError struct {
+	Duration time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("Task: timeout after %v", e.Duration)
+}
+
+// Report that the timeout error matches context.DeadlineExceeded
+func (e *TimeoutError) Is(target error) bool {
+	return target == context.DeadlineExceeded
+}
+
+// ------------------------------------------------------------------------------------------------------
+/* Timeout Option */
+
+type timeoutKey struct{}
+
+// Return a context that limits the execution time of the tasks created with it,
+// such task is faulted with TimeoutError if its body has not finished in time.
+// The timing starts when the body of Run / Start or the callback of Then / Catch / Continue starts,
+// and the context of a task created by RunContext is canceled on timeout.
+func WithTimeout(ctx context.Context, d time.Duration) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, timeoutKey{}, d)
+}
+
+// Start timing the task with the timeout of the context, return the function to stop timing
+func armTimeout(task *TaskImpl, ctx context.Context) func() bool {
+	if ctx == nil {
+		return nil
+	}
+	d, _ := ctx.Value(timeoutKey{}).(time.Duration)
+	if d <= 0 {
+		return nil
+	}
+	timer := clockOf(ctx).AfterFunc(d, func() {
+		reject(task, &TimeoutError{Duration: d})
+	})
+	return timer.Stop
+}
+
+// ------------------------------------------------------------------------------------------------------
+/* Timeout */
+
+// Create a task that adopts the outcome of the target task, or times out on the clock of the context
+func timeoutTask(t Task, d time.Duration, ctx context.Context, onTimeout func(task *TaskImpl)) Task {
+	if t == nil {
+		return Resolve()
+	}
+	if t.IsDone() {
+		return t
+	}
+	task := newTask()
+	timer := clockOf(ctx).AfterFunc(d, func() {
+		onTimeout(task)
+	})
+	observe(t, func(target Task) {
+		timer.Stop()
+		adopt(task, target)
+	})
+	return task
+}
+
+// Create a task that adopts the outcome of the target task,
+// or is faulted with TimeoutError if the target task is not done in time.
+// The time is measured by the clock of the context (WithClock).
+func Timeout(t Task, d time.Duration, ctxs ...context.Context) Task {
+	return timeoutTask(t, d, firstContext(ctxs, nil), func(task *TaskImpl) {
+		reject(task, &TimeoutError{Duration: d})
+	})
+}
+
+// Same as Timeout, and cancel the target task with TimeoutError on timeout.
+func TimeoutCancel(t Task, d time.Duration, ctxs ...context.Context) Task {
+	return timeoutTask(t, d, firstContext(ctxs, nil), func(task *TaskImpl) {
+		err := &TimeoutError{Duration: d}
+		reject(task, err)
+		cancelTask(t, err)
+	})
+}
+
+// Create a task that adopts the outcome of the target task,
+// or resolves to the fallback value if the target task is not done in time.
+func TimeoutOr(t Task, d time.Duration, fallback interface{}, ctxs ...context.Context) Task {
+	return timeoutTask(t, d, firstContext(ctxs, nil), func(task *TaskImpl) {
+		resolve(task, fallback)
+	})
+}
@@ -0,0 +1,75 @@
+package task_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pierre-primary/go-task"
+	"github.com/pierre-primary/go-task/tasktest"
+)
+
+func Test_Timeout(t *testing.T) {
+	t.Run("Timeout", func(t *testing.T) {
+		err := task.Timeout(task.Delay(time.Hour), time.Millisecond).Error()
+		var te *task.TimeoutError
+		if !errors.As(err, &te) || !errors.Is(err, context.DeadlineExceeded) {
+			t.Error("错误的结果", err)
+		}
+	})
+	t.Run("InTime", func(t *testing.T) {
+		rs := task.Timeout(task.Delay(time.Millisecond).Then(func(any) (any, error) {
+			return 1, nil
+		}), time.Hour).Result()
+		if rs != 1 {
+			t.Error("错误的结果", rs)
+		}
+	})
+	t.Run("Cancel", func(t *testing.T) {
+		src := task.Delay(time.Hour)
+		task.TimeoutCancel(src, time.Millisecond).Wait()
+		if src.Wait(); !src.IsCanceled() {
+			t.Error("错误的状态", src.State())
+		}
+	})
+	t.Run("Fallback", func(t *testing.T) {
+		if rs := task.TimeoutOr(task.Delay(time.Hour), time.Millisecond, "Fallback").Result(); rs != "Fallback" {
+			t.Error("错误的结果", rs)
+		}
+	})
+	t.Run("Clock", func(t *testing.T) {
+		clock := tasktest.NewClock(time.Unix(0, 0))
+		ctx := task.WithClock(context.Background(), clock)
+		src, _, _ := task.New()
+		tk := task.TimeoutOr(src, time.Minute, "Fallback", ctx)
+		if clock.Pending() != 1 {
+			t.Fatal("未使用上下文的时钟", clock.Pending())
+		}
+		clock.Advance(time.Minute)
+		if rs := tk.Result(); rs != "Fallback" {
+			t.Error("错误的结果", rs)
+		}
+	})
+	t.Run("Option", func(t *testing.T) {
+		ctx := task.WithTimeout(context.Background(), time.Millisecond)
+		stopped := make(chan struct{})
+		err := task.RunContext(func(ctx context.Context) (any, error) {
+			<-ctx.Done()
+			close(stopped)
+			return nil, ctx.Err()
+		}, ctx).Error()
+		<-stopped
+		var te *task.TimeoutError
+		if !errors.As(err, &te) {
+			t.Error("错误的结果", err)
+		}
+		err = task.Resolve().Then(func(any) (any, error) {
+			time.Sleep(10 * time.Millisecond)
+			return nil, nil
+		}, ctx).Error()
+		if !errors.As(err, &te) {
+			t.Error("错误的结果", err)
+		}
+	})
+}
@@ -0,0 +1,134 @@
+package task
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// 任务追踪
+//
+// 设置 Tracer 后（SetDefaultTracer 或 WithTracer），带任务函数的任务（Run, Start, RunContext 等）、
+// New 创建的任务以及所有 Follower 在创建时调用 Tracer.Begin 得到 TaskSpan，
+// 在任务函数开始执行、Follower 开始处理源任务结果、任务结束时调用 TaskSpan 的对应方法。
+// Follower 的 parent 为源任务的 TaskSpan，未指定 Tracer 时继承源任务的 Tracer。
+
+// 任务追踪器
+type Tracer interface {
+	// Called when a task is created, before the task is published.
+	// ctx is the context which creates the task, parent is the span of the source task of a follower.
+	// Return nil to not trace the task.
+	Begin(ctx context.Context, task Task, parent TaskSpan) TaskSpan
+}
+
+// 单个任务的追踪
+type TaskSpan interface {
+	// Called when the body of the task starts (StartCaller.Call), may be called again by Retry
+	Start()
+	// Called when the follower starts to handle the settled source task (FollowCaller.TryCall)
+	Follow(target Task)
+	// Called once the task is done, Wait of the task may return before or meanwhile.
+	// err is the error of a faulted or canceled task, elapsed is the duration since the task was created.
+	// A panic in End is recovered and ignored.
+	End(state TaskState, err error, elapsed time.Duration)
+}
+
+type taskTrace struct {
+	tracer  Tracer
+	span    TaskSpan
+	created time.Time
+}
+
+// ------------------------------------------------------------------------------------------------------
+/* Tracer Select */
+
+type (
+	tracerKey    struct{}
+	tracerHolder struct{ tracer Tracer }
+)
+
+var defaultTracer atomic.Value
+
+func init() {
+	defaultTracer.Store(tracerHolder{})
+}
+
+// Set the tracer used when the context does not specify one, nil disables tracing
+func SetDefaultTracer(tracer Tracer) {
+	defaultTracer.Store(tracerHolder{tracer})
+}
+
+// Get the default tracer
+func DefaultTracer() Tracer {
+	return defaultTracer.Load().(tracerHolder).tracer
+}
+
+// Return a context that traces the tasks created with it by the tracer
+func WithTracer(ctx context.Context, tracer Tracer) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, tracerKey{}, tracer)
+}
+
+// Get the tracer of the context, nil if tracing is disabled
+func tracerOf(ctx context.Context) Tracer {
+	if ctx != nil {
+		if tracer, ok := ctx.Value(tracerKey{}).(Tracer); ok && tracer != nil {
+			return tracer
+		}
+	}
+	return DefaultTracer()
+}
+
+// ------------------------------------------------------------------------------------------------------
+/* Trace Hooks */
+
+// Begin tracing the new task, parent is the source task of a follower,
+// must be called after the other options are applied and before the task is published.
+func beginTrace(task *TaskImpl, ctx context.Context, parent *TaskImpl) {
+	tracer := tracerOf(ctx)
+	var parentSpan TaskSpan
+	if parent != nil && parent.ext != nil && parent.ext.trace != nil {
+		if ctx == nil || ctx.Value(tracerKey{}) == nil {
+			tracer = parent.ext.trace.tracer
+		}
+		parentSpan = parent.ext.trace.span
+	}
+	if tracer == nil {
+		return
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	extOf(task).trace = &taskTrace{
+		tracer:  tracer,
+		span:    tracer.Begin(ctx, task, parentSpan),
+		created: time.Now(),
+	}
+}
+
+// Get the span of the task, nil if the task is not traced
+func spanOf(task *TaskImpl) TaskSpan {
+	if task.ext == nil || task.ext.trace == nil {
+		return nil
+	}
+	return task.ext.trace.span
+}
+
+// End tracing the task terminated with the state and data,
+// called once the task is unlocked, a panic of the span is recovered.
+func endTrace(task *TaskImpl, state uint32, data interface{}) {
+	span := spanOf(task)
+	if span == nil {
+		return
+	}
+	defer func() {
+		recover()
+	}()
+	var err error
+	if state != flagCompleted {
+		err, _ = data.(error)
+	}
+	span.End(stateOf(state), err, time.Since(task.ext.trace.created))
+}
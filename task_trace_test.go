@@ -0,0 +1,124 @@
+package task_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pierre-primary/go-task"
+)
+
+type recordTracer struct {
+	mu    sync.Mutex
+	spans []*recordSpan
+}
+
+type recordSpan struct {
+	tracer  *recordTracer
+	task    task.Task
+	parent  task.TaskSpan
+	events  []string
+	state   task.TaskState
+	err     error
+	elapsed time.Duration
+	ended   chan struct{}
+}
+
+func (r *recordTracer) Begin(ctx context.Context, t task.Task, parent task.TaskSpan) task.TaskSpan {
+	span := &recordSpan{tracer: r, task: t, parent: parent, ended: make(chan struct{})}
+	r.mu.Lock()
+	r.spans = append(r.spans, span)
+	r.mu.Unlock()
+	return span
+}
+
+func (s *recordSpan) record(event string) {
+	s.tracer.mu.Lock()
+	s.events = append(s.events, event)
+	s.tracer.mu.Unlock()
+}
+
+func (s *recordSpan) Start() {
+	s.record("start")
+}
+
+func (s *recordSpan) Follow(target task.Task) {
+	s.record("follow")
+}
+
+func (s *recordSpan) End(state task.TaskState, err error, elapsed time.Duration) {
+	s.tracer.mu.Lock()
+	s.state, s.err, s.elapsed = state, err, elapsed
+	s.tracer.mu.Unlock()
+	s.record("end")
+	close(s.ended)
+}
+
+type panicTracer struct{}
+
+func (panicTracer) Begin(ctx context.Context, t task.Task, parent task.TaskSpan) task.TaskSpan {
+	return panicSpan{}
+}
+
+type panicSpan struct{}
+
+func (panicSpan) Start()                                   {}
+func (panicSpan) Follow(task.Task)                         {}
+func (panicSpan) End(task.TaskState, error, time.Duration) { panic("End") }
+
+func Test_Tracer(t *testing.T) {
+	t.Run("Chain", func(t *testing.T) {
+		tracer := &recordTracer{}
+		ctx := task.WithTracer(context.Background(), tracer)
+		rejectErr := errors.New("Reject")
+		src := task.Run(func() (any, error) {
+			time.Sleep(time.Millisecond)
+			return nil, rejectErr
+		}, ctx)
+		// the follower inherits the tracer of the source task
+		src.Catch(func(err error) (any, error) {
+			return nil, nil
+		}).Wait()
+
+		tracer.mu.Lock()
+		spans := tracer.spans
+		tracer.mu.Unlock()
+		if len(spans) != 2 {
+			t.Fatal("错误的 span 数量", len(spans))
+		}
+		s, f := spans[0], spans[1]
+		// End may run after Wait returns
+		<-s.ended
+		<-f.ended
+		tracer.mu.Lock()
+		defer tracer.mu.Unlock()
+		if s.parent != nil || f.parent != task.TaskSpan(s) {
+			t.Error("错误的父子关系")
+		}
+		if len(s.events) != 2 || s.events[0] != "start" || s.events[1] != "end" {
+			t.Error("错误的事件", s.events)
+		}
+		if len(f.events) != 2 || f.events[0] != "follow" || f.events[1] != "end" {
+			t.Error("错误的事件", f.events)
+		}
+		if s.state != task.STATE_FAULTED || s.err != rejectErr || s.elapsed < time.Millisecond {
+			t.Error("错误的结束状态", s.state, s.err, s.elapsed)
+		}
+		if f.state != task.STATE_COMPLETED || f.err != nil {
+			t.Error("错误的结束状态", f.state, f.err)
+		}
+	})
+	t.Run("PanicEnd", func(t *testing.T) {
+		ctx := task.WithTracer(context.Background(), panicTracer{})
+		rs := task.Run(func() (any, error) {
+			return 1, nil
+		}, ctx).Then(func(v any) (any, error) {
+			return v.(int) + 1, nil
+		}).Result()
+		if rs != 2 {
+			t.Error("错误的结果", rs)
+		}
+	})
+}
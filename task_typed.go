@@ -0,0 +1,201 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// 泛型任务定义
+//
+// TaskOf 是 Task 的类型化视图，与 Task 共享同一个 TaskImpl 状态机，
+// 可通过 Untyped 和 AsTaskOf 在两套 API 之间互相转换。
+type TaskOf[T any] struct {
+	impl *TaskImpl
+}
+
+// ------------------------------------------------------------------------------------------------------
+// 实现 TaskOf 的方法
+
+// Return the untyped task
+func (t TaskOf[T]) Untyped() Task {
+	return t.impl
+}
+
+func (t TaskOf[T]) State() TaskState {
+	return t.impl.State()
+}
+
+func (t TaskOf[T]) IsDone() bool {
+	return t.impl.IsDone()
+}
+
+func (t TaskOf[T]) IsCompleted() bool {
+	return t.impl.IsCompleted()
+}
+
+func (t TaskOf[T]) IsFaulted() bool {
+	return t.impl.IsFaulted()
+}
+
+func (t TaskOf[T]) IsCanceled() bool {
+	return t.impl.IsCanceled()
+}
+
+func (t TaskOf[T]) Return() (T, error) {
+	rs, err := t.impl.Return()
+	v, _ := rs.(T)
+	return v, err
+}
+
+func (t TaskOf[T]) Result() T {
+	v, _ := t.impl.Result().(T)
+	return v
+}
+
+func (t TaskOf[T]) Error() error {
+	return t.impl.Error()
+}
+
+func (t TaskOf[T]) Cancel(cause error) bool {
+	return t.impl.Cancel(cause)
+}
+
+func (t TaskOf[T]) Info() TaskInfo {
+	return t.impl.Info()
+}
+
+func (t TaskOf[T]) Done() chan struct{} {
+	return t.impl.Done()
+}
+
+func (t TaskOf[T]) Wait(ctxs ...context.Context) TaskOf[T] {
+	t.impl.Wait(ctxs...)
+	return t
+}
+
+func (t TaskOf[T]) WaitTimeout(d time.Duration, ctxs ...context.Context) TaskOf[T] {
+	t.impl.WaitTimeout(d, ctxs...)
+	return t
+}
+
+func (t TaskOf[T]) Finally(fn FinallyFunc, ctxs ...context.Context) TaskOf[T] {
+	return TaskOf[T]{t.impl.Finally(fn, ctxs...).(*TaskImpl)}
+}
+
+// ------------------------------------------------------------------------------------------------------
+// 工厂方法
+
+/* New */
+func NewOf[T any]() (TaskOf[T], func(T), RejectFunc) {
+	task := newContextTask(nil)
+	return TaskOf[T]{task}, func(result T) {
+			terminate(task, flagCompleted, result)
+		}, func(err error) {
+			reject(task, err)
+		}
+}
+
+/* Resolve */
+func ResolveOf[T any](result T) TaskOf[T] {
+	return TaskOf[T]{newDoneTask(flagCompleted, result)}
+}
+
+/* Reject */
+func RejectOf[T any](err error) TaskOf[T] {
+	return TaskOf[T]{Reject(err).(*TaskImpl)}
+}
+
+/* Run */
+type runOfCaller[T any] func() (T, error)
+
+func (body runOfCaller[T]) Call(task *TaskImpl) {
+	if rs, err := body(); err == nil {
+		terminate(task, flagCompleted, rs)
+	} else {
+		reject(task, err)
+	}
+}
+
+func RunOf[T any](fn func() (T, error), ctxs ...context.Context) TaskOf[T] {
+	ctx := firstContext(ctxs, nil)
+	if ctx != nil && isCanceledContext(ctx) {
+		return TaskOf[T]{Cancel(ctx.Err()).(*TaskImpl)}
+	}
+	return TaskOf[T]{newStarter(runOfCaller[T](fn), ctx)}
+}
+
+/* Then */
+type thenOfCaller[T, U any] func(T) (U, error)
+
+func (body thenOfCaller[T, U]) TryCall(target Task) (bool, interface{}, error) {
+	if body == nil || !target.IsCompleted() {
+		return false, nil, nil
+	}
+	v, _ := target.Result().(T)
+	rs, err := body(v)
+	return true, rs, err
+}
+
+func ThenOf[T, U any](t TaskOf[T], fn func(T) (U, error), ctxs ...context.Context) TaskOf[U] {
+	ctx := firstContext(ctxs, nil)
+	if ctx != nil && isCanceledContext(ctx) {
+		return TaskOf[U]{Cancel(ctx.Err()).(*TaskImpl)}
+	}
+	return TaskOf[U]{newAsyncFollower(t.impl, thenOfCaller[T, U](fn), ctx)}
+}
+
+/* Catch */
+type catchOfCaller[T any] func(error) (T, error)
+
+func (body catchOfCaller[T]) TryCall(target Task) (bool, interface{}, error) {
+	if body == nil || !target.IsFaulted() {
+		return false, nil, nil
+	}
+	rs, err := body(target.Error())
+	return true, rs, err
+}
+
+func CatchOf[T any](t TaskOf[T], fn func(error) (T, error), ctxs ...context.Context) TaskOf[T] {
+	ctx := firstContext(ctxs, nil)
+	if ctx != nil && isCanceledContext(ctx) {
+		return TaskOf[T]{Cancel(ctx.Err()).(*TaskImpl)}
+	}
+	return TaskOf[T]{newAsyncFollower(t.impl, catchOfCaller[T](fn), ctx)}
+}
+
+// ------------------------------------------------------------------------------------------------------
+// 类型转换
+
+type convertCaller[T any] struct{}
+
+func (convertCaller[T]) TryCall(target Task) (bool, interface{}, error) {
+	if !target.IsCompleted() {
+		return false, nil, nil
+	}
+	rs := target.Result()
+	if _, ok := rs.(T); ok || rs == nil {
+		// passing the result
+		return false, nil, nil
+	}
+	return true, nil, fmt.Errorf("Task: result of type %T is not %v", rs, reflect.TypeOf((*T)(nil)).Elem())
+}
+
+// Convert an untyped task to a typed task,
+// the typed task is faulted if the result is not of type T.
+func AsTaskOf[T any](t Task) TaskOf[T] {
+	if t == nil {
+		var zero T
+		return ResolveOf(zero)
+	}
+	task, ok := t.(*TaskImpl)
+	if !ok {
+		task = newTask()
+		t.Continue(func(target Task) (interface{}, error) {
+			adopt(task, target)
+			return nil, nil
+		})
+	}
+	return TaskOf[T]{newAsyncFollower(task, convertCaller[T]{}, nil)}
+}
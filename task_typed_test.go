@@ -0,0 +1,41 @@
+package task_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/pierre-primary/go-task"
+)
+
+func Test_TaskOf(t *testing.T) {
+	t.Run("Then", func(t *testing.T) {
+		n := task.RunOf(func() (int, error) {
+			return 42, nil
+		})
+		s := task.ThenOf(n, func(v int) (string, error) {
+			return strconv.Itoa(v), nil
+		})
+		if rs, err := s.Return(); err != nil || rs != "42" {
+			t.Error("错误的结果", rs, err)
+		}
+	})
+	t.Run("Catch", func(t *testing.T) {
+		n := task.CatchOf(task.RejectOf[int](errors.New("Reject")), func(err error) (int, error) {
+			return 1, nil
+		})
+		if n.Result() != 1 {
+			t.Error("错误的结果", n.Result())
+		}
+	})
+	t.Run("Untyped", func(t *testing.T) {
+		n := task.AsTaskOf[int](task.ResolveOf(7).Untyped())
+		if n.Result() != 7 {
+			t.Error("错误的结果", n.Result())
+		}
+		s := task.AsTaskOf[string](task.Resolve(7)).Wait()
+		if !s.IsFaulted() {
+			t.Error("错误的状态", s.State())
+		}
+	})
+}
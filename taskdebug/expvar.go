@@ -0,0 +1,25 @@
+// Package taskdebug exposes the runtime state of tasks for debugging,
+// it is separate from the task package to keep expvar and net/http out of programs that do not use it.
+package taskdebug
+
+import (
+	"expvar"
+
+	"github.com/pierre-primary/go-task"
+)
+
+// Default name of the published metrics
+const ExpvarName = "task"
+
+// Enable the metrics and publish their snapshot (task.Stats) as an expvar variable,
+// name defaults to ExpvarName. Like expvar.Publish, it panics if the name is already in use.
+func PublishExpvar(name ...string) {
+	n := ExpvarName
+	if len(name) > 0 && name[0] != "" {
+		n = name[0]
+	}
+	task.EnableMetrics()
+	expvar.Publish(n, expvar.Func(func() interface{} {
+		return task.Stats()
+	}))
+}
@@ -0,0 +1,34 @@
+package taskdebug_test
+
+import (
+	"encoding/json"
+	"expvar"
+	"sync"
+	"testing"
+
+	"github.com/pierre-primary/go-task"
+	"github.com/pierre-primary/go-task/taskdebug"
+)
+
+// expvar.Publish panics on a name in use, publish once across -count runs
+var publishOnce sync.Once
+
+func Test_PublishExpvar(t *testing.T) {
+	publishOnce.Do(func() {
+		taskdebug.PublishExpvar("task_test")
+	})
+	task.Run(func() (any, error) {
+		return nil, nil
+	}).Wait()
+	v := expvar.Get("task_test")
+	if v == nil {
+		t.Fatal("未发布指标")
+	}
+	var stats task.Metrics
+	if err := json.Unmarshal([]byte(v.String()), &stats); err != nil {
+		t.Fatal(err)
+	}
+	if stats.Created == 0 || stats.Settled[task.STATE_COMPLETED] == 0 {
+		t.Error("错误的指标", v.String())
+	}
+}
@@ -0,0 +1,48 @@
+package taskdebug
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/pierre-primary/go-task"
+)
+
+// Path on which the graph handler is registered with http.DefaultServeMux
+const GraphPath = "/debug/tasks"
+
+func init() {
+	http.Handle(GraphPath, Handler())
+}
+
+// Return a handler that serves the wait graph of the registered tasks (task.DumpGraph),
+// the format is chosen by the query parameter "format": text (default), json or dot.
+// Like net/http/pprof, importing the package registers it on GraphPath of http.DefaultServeMux.
+func Handler() http.Handler {
+	return http.HandlerFunc(serveGraph)
+}
+
+func serveGraph(w http.ResponseWriter, r *http.Request) {
+	var (
+		format      task.GraphFormat
+		contentType string
+	)
+	switch r.FormValue("format") {
+	case "", "text":
+		format, contentType = task.GRAPH_TEXT, "text/plain; charset=utf-8"
+	case "json":
+		format, contentType = task.GRAPH_JSON, "application/json"
+	case "dot":
+		format, contentType = task.GRAPH_DOT, "text/vnd.graphviz; charset=utf-8"
+	default:
+		http.Error(w, "unknown format, want text, json or dot", http.StatusBadRequest)
+		return
+	}
+	var buf bytes.Buffer
+	if err := task.DumpGraph(&buf, format); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Write(buf.Bytes())
+}
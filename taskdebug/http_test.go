@@ -0,0 +1,39 @@
+package taskdebug_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pierre-primary/go-task"
+	"github.com/pierre-primary/go-task/taskdebug"
+)
+
+func Test_Handler(t *testing.T) {
+	disable := task.EnableTracking()
+	defer disable()
+	t1, resolve, _ := task.New()
+	defer func() {
+		resolve(nil)
+		t1.Wait()
+	}()
+
+	for format, want := range map[string]string{
+		"":     "[pending]",
+		"json": `"nodes"`,
+		"dot":  "digraph tasks",
+	} {
+		rec := httptest.NewRecorder()
+		taskdebug.Handler().ServeHTTP(rec, httptest.NewRequest("GET", taskdebug.GraphPath+"?format="+format, nil))
+		if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), want) {
+			t.Error("错误的响应", format, rec.Code, rec.Body.String())
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	http.DefaultServeMux.ServeHTTP(rec, httptest.NewRequest("GET", taskdebug.GraphPath+"?format=svg", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Error("未知格式应返回 400", rec.Code)
+	}
+}
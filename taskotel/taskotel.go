@@ -0,0 +1,145 @@
+// Package taskotel adapts task tracing to OpenTelemetry.
+//
+// The package depends only on the small interfaces below, which mirror the parts of
+// go.opentelemetry.io/otel/trace it uses, so it adds no dependency to the module.
+// Wrap an OpenTelemetry tracer in a few lines:
+//
+//	type otelTracer struct{ trace.Tracer }
+//
+//	func (t otelTracer) Start(ctx context.Context, name string) (context.Context, taskotel.Span) {
+//		ctx, span := t.Tracer.Start(ctx, name)
+//		return ctx, otelSpan{span}
+//	}
+//
+// then install it with task.SetDefaultTracer(taskotel.New(otelTracer{otel.Tracer("task")})).
+//
+// Every traced task becomes a span: an initial task is a child of the span in the context
+// that creates it, a follower is a child of the span of its source task.
+package taskotel
+
+import (
+	"context"
+	"time"
+
+	"github.com/pierre-primary/go-task"
+)
+
+// Tracer starts spans, the subset of an OpenTelemetry tracer used by the adapter
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span records a traced task, the subset of an OpenTelemetry span used by the adapter
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	AddEvent(name string)
+	SetStatus(code StatusCode, description string)
+	End()
+}
+
+// Attribute is a key/value pair attached to a span
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// StatusCode is the status of a span, the values match OpenTelemetry codes.Code
+type StatusCode uint32
+
+const (
+	Unset StatusCode = 0
+	Error StatusCode = 1
+	Ok    StatusCode = 2
+)
+
+// Names of the attributes and events recorded by the adapter
+const (
+	AttrSite     = "task.site"
+	AttrLabel    = "task.label."
+	AttrState    = "task.state"
+	AttrDuration = "task.duration"
+	EventStart   = "task.start"
+	EventFollow  = "task.follow"
+)
+
+// Default name of the span of a task without a name
+const DefaultSpanName = "task"
+
+type tracer struct {
+	tracer Tracer
+}
+
+// Return a task tracer that records each task as a span of the tracer
+func New(t Tracer) task.Tracer {
+	return &tracer{tracer: t}
+}
+
+func (t *tracer) Begin(ctx context.Context, tk task.Task, parent task.TaskSpan) task.TaskSpan {
+	if p, ok := parent.(*span); ok {
+		ctx = p.ctx
+	}
+	var info task.TaskInfo
+	if t, ok := tk.(interface{ Info() task.TaskInfo }); ok {
+		info = t.Info()
+	}
+	name := info.Name
+	if name == "" {
+		name = DefaultSpanName
+	}
+	ctx, s := t.tracer.Start(ctx, name)
+	attrs := make([]Attribute, 0, 1+len(info.Labels))
+	if info.Site != "" {
+		attrs = append(attrs, Attribute{AttrSite, info.Site})
+	}
+	for k, v := range info.Labels {
+		attrs = append(attrs, Attribute{AttrLabel + k, v})
+	}
+	if len(attrs) > 0 {
+		s.SetAttributes(attrs...)
+	}
+	return &span{ctx: ctx, span: s}
+}
+
+type span struct {
+	ctx  context.Context
+	span Span
+}
+
+func (s *span) Start() {
+	s.span.AddEvent(EventStart)
+}
+
+func (s *span) Follow(target task.Task) {
+	s.span.AddEvent(EventFollow)
+}
+
+func (s *span) End(state task.TaskState, err error, elapsed time.Duration) {
+	s.span.SetAttributes(
+		Attribute{AttrState, stateName(state)},
+		Attribute{AttrDuration, elapsed},
+	)
+	switch state {
+	case task.STATE_COMPLETED:
+		s.span.SetStatus(Ok, "")
+	case task.STATE_FAULTED:
+		desc := ""
+		if err != nil {
+			desc = err.Error()
+		}
+		s.span.SetStatus(Error, desc)
+	}
+	s.span.End()
+}
+
+func stateName(state task.TaskState) string {
+	switch state {
+	case task.STATE_COMPLETED:
+		return "completed"
+	case task.STATE_FAULTED:
+		return "faulted"
+	case task.STATE_CANCELED:
+		return "canceled"
+	default:
+		return "pending"
+	}
+}
@@ -0,0 +1,124 @@
+package taskotel_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pierre-primary/go-task"
+	"github.com/pierre-primary/go-task/taskotel"
+)
+
+// In-memory exporter, records the ended spans
+type exporter struct {
+	mu    sync.Mutex
+	seq   int
+	ended []*memSpan
+}
+
+type spanKey struct{}
+
+type memSpan struct {
+	exp    *exporter
+	id     int
+	parent int
+	name   string
+	attrs  map[string]interface{}
+	events []string
+	code   taskotel.StatusCode
+	desc   string
+}
+
+func (e *exporter) Start(ctx context.Context, name string) (context.Context, taskotel.Span) {
+	e.mu.Lock()
+	e.seq++
+	span := &memSpan{exp: e, id: e.seq, name: name, attrs: map[string]interface{}{}}
+	e.mu.Unlock()
+	if parent, ok := ctx.Value(spanKey{}).(*memSpan); ok {
+		span.parent = parent.id
+	}
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+func (s *memSpan) SetAttributes(attrs ...taskotel.Attribute) {
+	for _, attr := range attrs {
+		s.attrs[attr.Key] = attr.Value
+	}
+}
+
+func (s *memSpan) AddEvent(name string) {
+	s.events = append(s.events, name)
+}
+
+func (s *memSpan) SetStatus(code taskotel.StatusCode, desc string) {
+	s.code, s.desc = code, desc
+}
+
+func (s *memSpan) End() {
+	s.exp.mu.Lock()
+	s.exp.ended = append(s.exp.ended, s)
+	s.exp.mu.Unlock()
+}
+
+// Wait until n spans are ended, End may run after Wait of the task returns
+func (e *exporter) waitEnded(n int) {
+	for {
+		e.mu.Lock()
+		ended := len(e.ended)
+		e.mu.Unlock()
+		if ended >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func (e *exporter) byName(name string) *memSpan {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, span := range e.ended {
+		if span.name == name {
+			return span
+		}
+	}
+	return nil
+}
+
+func Test_Adapter(t *testing.T) {
+	exp := &exporter{}
+	root, _ := exp.Start(context.Background(), "request")
+	ctx := task.WithTracer(root, taskotel.New(exp))
+	ctx = task.WithLabels(task.WithName(ctx, "fetch"), "user", "42")
+
+	rejectErr := errors.New("Reject")
+	src := task.Run(func() (any, error) {
+		return nil, rejectErr
+	}, ctx)
+	src.Then(func(any) (any, error) {
+		return nil, nil
+	}, task.WithName(context.Background(), "parse")).Wait()
+	exp.waitEnded(2)
+
+	fetch, parse := exp.byName("fetch"), exp.byName("parse")
+	if fetch == nil || parse == nil {
+		t.Fatal("未导出 span")
+	}
+	if fetch.parent != 1 || parse.parent != fetch.id {
+		t.Error("错误的父子关系", fetch.parent, parse.parent)
+	}
+	if fetch.code != taskotel.Error || fetch.desc == "" || fetch.attrs[taskotel.AttrState] != "faulted" {
+		t.Error("错误的状态", fetch.code, fetch.desc, fetch.attrs)
+	}
+	if fetch.attrs[taskotel.AttrLabel+"user"] != "42" || fetch.attrs[taskotel.AttrSite] == nil {
+		t.Error("错误的属性", fetch.attrs)
+	}
+	if len(fetch.events) != 1 || fetch.events[0] != taskotel.EventStart {
+		t.Error("错误的事件", fetch.events)
+	}
+	// the follower passes the failure of the source task
+	if len(parse.events) != 1 || parse.events[0] != taskotel.EventFollow || parse.code != taskotel.Error {
+		t.Error("错误的事件", parse.events, parse.code)
+	}
+}
@@ -0,0 +1,129 @@
+package tasktest
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pierre-primary/go-task"
+)
+
+// 虚拟时钟
+//
+// Clock 只在 Advance / Set 时推进，到期的计时器在调用者的 goroutine 中按到期顺序触发。
+type Clock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*timer
+}
+
+type timer struct {
+	clock *Clock
+	when  time.Time
+	ch    chan time.Time
+	fn    func()
+}
+
+var _ task.Clock = (*Clock)(nil)
+
+// Create a virtual clock starting at the time
+func NewClock(start time.Time) *Clock {
+	return &Clock{now: start}
+}
+
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *Clock) NewTimer(d time.Duration) task.Timer {
+	return c.add(d, make(chan time.Time, 1), nil)
+}
+
+func (c *Clock) AfterFunc(d time.Duration, fn func()) task.Timer {
+	return c.add(d, nil, fn)
+}
+
+func (c *Clock) add(d time.Duration, ch chan time.Time, fn func()) *timer {
+	c.mu.Lock()
+	t := &timer{clock: c, when: c.now.Add(d), ch: ch, fn: fn}
+	c.timers = append(c.timers, t)
+	c.mu.Unlock()
+	if d <= 0 {
+		c.Advance(0)
+	}
+	return t
+}
+
+// Number of timers that have not fired or stopped
+func (c *Clock) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.timers)
+}
+
+// Advance the clock by the duration, and fire the expired timers
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.set(c.now.Add(d))
+}
+
+// Set the clock to the time, and fire the expired timers
+func (c *Clock) Set(now time.Time) {
+	c.mu.Lock()
+	c.set(now)
+}
+
+// Must be called while holding the lock, the lock is released
+func (c *Clock) set(now time.Time) {
+	if now.After(c.now) {
+		c.now = now
+	}
+	now = c.now
+	var expired []*timer
+	remain := c.timers[:0]
+	for _, t := range c.timers {
+		if t.when.After(now) {
+			remain = append(remain, t)
+		} else {
+			expired = append(expired, t)
+		}
+	}
+	for i := len(remain); i < len(c.timers); i++ {
+		c.timers[i] = nil
+	}
+	c.timers = remain
+	c.mu.Unlock()
+
+	sort.SliceStable(expired, func(i, j int) bool {
+		return expired[i].when.Before(expired[j].when)
+	})
+	for _, t := range expired {
+		if t.fn != nil {
+			t.fn()
+		} else {
+			select {
+			case t.ch <- now:
+			default:
+			}
+		}
+	}
+}
+
+func (t *timer) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *timer) Stop() bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, other := range c.timers {
+		if other == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package tasktest
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pierre-primary/go-task"
+)
+
+// 测试接口（testing.TB 的子集）
+type TB interface {
+	Helper()
+	Cleanup(func())
+	Errorf(format string, args ...interface{})
+}
+
+// 等待任务结束的宽限时间
+var VerifyGrace = 100 * time.Millisecond
+
+// Fail the test if any task created during it is still pending at the end,
+// should be called at the beginning of the test.
+// Tasks created by other tests running in parallel are counted as well.
+func VerifyNoPending(t TB) {
+	t.Helper()
+	disable := task.EnableTracking()
+	start := task.TrackingSeq()
+	t.Cleanup(func() {
+		defer disable()
+		deadline := time.Now().Add(VerifyGrace)
+		for {
+			pending := pendingSince(start)
+			if len(pending) == 0 {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Errorf("%s", formatPending(pending))
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	})
+}
+
+func pendingSince(start uint64) []task.LiveTask {
+	var pending []task.LiveTask
+	for _, live := range task.LiveTasks() {
+		if live.ID > start && live.State == task.STATE_PENDING {
+			pending = append(pending, live)
+		}
+	}
+	return pending
+}
+
+func formatPending(pending []task.LiveTask) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d task(s) still pending:", len(pending))
+	for _, live := range pending {
+		fmt.Fprintf(&b, "\n\t#%d created at %s, %d follower(s) waiting", live.ID, live.Site, live.Followers)
+	}
+	return b.String()
+}
@@ -0,0 +1,56 @@
+package tasktest_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pierre-primary/go-task"
+	"github.com/pierre-primary/go-task/tasktest"
+)
+
+type fakeTB struct {
+	cleanups []func()
+	errors   []string
+}
+
+func (tb *fakeTB) Helper() {}
+
+func (tb *fakeTB) Cleanup(fn func()) {
+	tb.cleanups = append(tb.cleanups, fn)
+}
+
+func (tb *fakeTB) Errorf(format string, args ...interface{}) {
+	tb.errors = append(tb.errors, fmt.Sprintf(format, args...))
+}
+
+func (tb *fakeTB) finish() {
+	for i := len(tb.cleanups) - 1; i >= 0; i-- {
+		tb.cleanups[i]()
+	}
+}
+
+func Test_VerifyNoPending(t *testing.T) {
+	t.Run("Clean", func(t *testing.T) {
+		tasktest.VerifyNoPending(t)
+		task.Run(func() (interface{}, error) {
+			return nil, nil
+		}).Then(func(interface{}) (interface{}, error) {
+			return nil, nil
+		}).Wait()
+	})
+	t.Run("Leak", func(t *testing.T) {
+		tb := &fakeTB{}
+		tasktest.VerifyNoPending(tb)
+		src, resolve, _ := task.New()
+		src.Then(func(interface{}) (interface{}, error) {
+			return nil, nil
+		})
+		tb.finish()
+		resolve(nil)
+		if len(tb.errors) != 1 || !strings.Contains(tb.errors[0], "leak_test.go") ||
+			!strings.Contains(tb.errors[0], "1 follower(s)") {
+			t.Error("错误的结果", tb.errors)
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package tasktest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pierre-primary/go-task"
+)
+
+// 手动调度器
+//
+// Scheduler 是一个只排队不执行的 Executor，启动器（Run / Start / Delay）和 Follower（Then / Catch / Continue）
+// 在测试调用 Step / RunUntilIdle 时于调用者的 goroutine 中逐个执行，从而使异步流程可复现。
+type Scheduler struct {
+	mu    sync.Mutex
+	queue []func()
+}
+
+var _ task.Executor = (*Scheduler)(nil)
+
+// Create a manual scheduler
+func NewScheduler() *Scheduler {
+	return &Scheduler{}
+}
+
+func (s *Scheduler) Execute(fn func()) error {
+	s.mu.Lock()
+	s.queue = append(s.queue, fn)
+	s.mu.Unlock()
+	return nil
+}
+
+// Number of queued functions
+func (s *Scheduler) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.queue)
+}
+
+// Run the first queued function, return false if the queue is empty
+func (s *Scheduler) Step() bool {
+	s.mu.Lock()
+	if len(s.queue) == 0 {
+		s.mu.Unlock()
+		return false
+	}
+	fn := s.queue[0]
+	s.queue[0] = nil
+	s.queue = s.queue[1:]
+	s.mu.Unlock()
+	fn()
+	return true
+}
+
+// Run the queued functions until the queue is empty, including the newly queued ones,
+// return the number of functions run.
+func (s *Scheduler) RunUntilIdle() int {
+	n := 0
+	for s.Step() {
+		n++
+	}
+	return n
+}
+
+// Return a context that schedules the tasks created with it on the scheduler,
+// and times them by the clock if it is not nil.
+func NewContext(parent context.Context, s *Scheduler, c *Clock) context.Context {
+	ctx := task.WithExecutor(parent, s)
+	if c != nil {
+		ctx = task.WithClock(ctx, c)
+	}
+	return ctx
+}
@@ -0,0 +1,72 @@
+package tasktest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pierre-primary/go-task"
+	"github.com/pierre-primary/go-task/tasktest"
+)
+
+func Test_Delay(t *testing.T) {
+	sched := tasktest.NewScheduler()
+	clock := tasktest.NewClock(time.Unix(0, 0))
+	ctx := tasktest.NewContext(context.Background(), sched, clock)
+
+	tk := task.Delay(time.Second, ctx).Then(func(interface{}) (interface{}, error) {
+		return 1, nil
+	}, ctx)
+	sched.RunUntilIdle()
+	clock.Advance(999 * time.Millisecond)
+	sched.RunUntilIdle()
+	if tk.IsDone() {
+		t.Fatal("错误的状态", tk.State())
+	}
+	clock.Advance(time.Millisecond)
+	if sched.RunUntilIdle() != 1 || tk.Result() != 1 {
+		t.Error("错误的结果", tk.State())
+	}
+}
+
+func Test_Retry(t *testing.T) {
+	sched := tasktest.NewScheduler()
+	clock := tasktest.NewClock(time.Unix(0, 0))
+	ctx := tasktest.NewContext(context.Background(), sched, clock)
+
+	attempts := 0
+	tk := task.Retry(func() (interface{}, error) {
+		attempts++
+		return nil, errors.New("Retry")
+	}, task.RetryPolicy{
+		MaxAttempts: 3,
+		Backoff:     task.ConstantBackoff(time.Minute),
+	}, ctx)
+	for i := 1; i <= 3; i++ {
+		sched.RunUntilIdle()
+		if attempts != i {
+			t.Fatal("错误的次数", attempts)
+		}
+		clock.Advance(time.Minute)
+	}
+	if !tk.IsFaulted() {
+		t.Error("错误的状态", tk.State())
+	}
+}
+
+func Test_WaitTimeout(t *testing.T) {
+	clock := tasktest.NewClock(time.Unix(0, 0))
+	ctx := task.WithClock(context.Background(), clock)
+	src, _, _ := task.New()
+	done := make(chan struct{})
+	go func() {
+		src.WaitTimeout(time.Second, ctx)
+		close(done)
+	}()
+	for clock.Pending() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	clock.Advance(time.Second)
+	<-done
+}